@@ -23,31 +23,134 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type UploadFileUnaryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	CourseId      string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
-	GroupId       string                 `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"` // файл целиком
-	MimeType      string                 `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+type FileSortField int32
+
+const (
+	FileSortField_FILE_SORT_FIELD_CREATED_AT FileSortField = 0
+	FileSortField_FILE_SORT_FIELD_NAME       FileSortField = 1
+	FileSortField_FILE_SORT_FIELD_SIZE       FileSortField = 2
+)
+
+// Enum value maps for FileSortField.
+var (
+	FileSortField_name = map[int32]string{
+		0: "FILE_SORT_FIELD_CREATED_AT",
+		1: "FILE_SORT_FIELD_NAME",
+		2: "FILE_SORT_FIELD_SIZE",
+	}
+	FileSortField_value = map[string]int32{
+		"FILE_SORT_FIELD_CREATED_AT": 0,
+		"FILE_SORT_FIELD_NAME":       1,
+		"FILE_SORT_FIELD_SIZE":       2,
+	}
+)
+
+func (x FileSortField) Enum() *FileSortField {
+	p := new(FileSortField)
+	*p = x
+	return p
+}
+
+func (x FileSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_public_fl_proto_enumTypes[0].Descriptor()
+}
+
+func (FileSortField) Type() protoreflect.EnumType {
+	return &file_file_public_fl_proto_enumTypes[0]
+}
+
+func (x FileSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileSortField.Descriptor instead.
+func (FileSortField) EnumDescriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{0}
+}
+
+// Статус проверки содержимого на вредоносное ПО,
+// скачать можно только SCAN_STATUS_CLEAN
+type ScanStatus int32
+
+const (
+	ScanStatus_SCAN_STATUS_PENDING  ScanStatus = 0
+	ScanStatus_SCAN_STATUS_CLEAN    ScanStatus = 1
+	ScanStatus_SCAN_STATUS_INFECTED ScanStatus = 2
+	ScanStatus_SCAN_STATUS_FAILED   ScanStatus = 3 // сканер отказался проверять содержимое
+)
+
+// Enum value maps for ScanStatus.
+var (
+	ScanStatus_name = map[int32]string{
+		0: "SCAN_STATUS_PENDING",
+		1: "SCAN_STATUS_CLEAN",
+		2: "SCAN_STATUS_INFECTED",
+		3: "SCAN_STATUS_FAILED",
+	}
+	ScanStatus_value = map[string]int32{
+		"SCAN_STATUS_PENDING":  0,
+		"SCAN_STATUS_CLEAN":    1,
+		"SCAN_STATUS_INFECTED": 2,
+		"SCAN_STATUS_FAILED":   3,
+	}
+)
+
+func (x ScanStatus) Enum() *ScanStatus {
+	p := new(ScanStatus)
+	*p = x
+	return p
+}
+
+func (x ScanStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScanStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_public_fl_proto_enumTypes[1].Descriptor()
+}
+
+func (ScanStatus) Type() protoreflect.EnumType {
+	return &file_file_public_fl_proto_enumTypes[1]
+}
+
+func (x ScanStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScanStatus.Descriptor instead.
+func (ScanStatus) EnumDescriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{1}
+}
+
+type UploadFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadFileRequest_Metadata
+	//	*UploadFileRequest_Chunk
+	Data          isUploadFileRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadFileUnaryRequest) Reset() {
-	*x = UploadFileUnaryRequest{}
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
 	mi := &file_file_public_fl_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadFileUnaryRequest) String() string {
+func (x *UploadFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadFileUnaryRequest) ProtoMessage() {}
+func (*UploadFileRequest) ProtoMessage() {}
 
-func (x *UploadFileUnaryRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_file_public_fl_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -59,67 +162,75 @@ func (x *UploadFileUnaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadFileUnaryRequest.ProtoReflect.Descriptor instead.
-func (*UploadFileUnaryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
 	return file_file_public_fl_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *UploadFileUnaryRequest) GetFilename() string {
+func (x *UploadFileRequest) GetData() isUploadFileRequest_Data {
 	if x != nil {
-		return x.Filename
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *UploadFileUnaryRequest) GetCourseId() string {
+func (x *UploadFileRequest) GetMetadata() *FileMetadata {
 	if x != nil {
-		return x.CourseId
+		if x, ok := x.Data.(*UploadFileRequest_Metadata); ok {
+			return x.Metadata
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *UploadFileUnaryRequest) GetGroupId() string {
+func (x *UploadFileRequest) GetChunk() []byte {
 	if x != nil {
-		return x.GroupId
+		if x, ok := x.Data.(*UploadFileRequest_Chunk); ok {
+			return x.Chunk
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *UploadFileUnaryRequest) GetContent() []byte {
-	if x != nil {
-		return x.Content
-	}
-	return nil
+type isUploadFileRequest_Data interface {
+	isUploadFileRequest_Data()
 }
 
-func (x *UploadFileUnaryRequest) GetMimeType() string {
-	if x != nil {
-		return x.MimeType
-	}
-	return ""
+type UploadFileRequest_Metadata struct {
+	Metadata *FileMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
 }
 
-type UploadFileUnaryResponse struct {
+type UploadFileRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadFileRequest_Metadata) isUploadFileRequest_Data() {}
+
+func (*UploadFileRequest_Chunk) isUploadFileRequest_Data() {}
+
+type UploadFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadFileUnaryResponse) Reset() {
-	*x = UploadFileUnaryResponse{}
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
 	mi := &file_file_public_fl_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadFileUnaryResponse) String() string {
+func (x *UploadFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadFileUnaryResponse) ProtoMessage() {}
+func (*UploadFileResponse) ProtoMessage() {}
 
-func (x *UploadFileUnaryResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_file_public_fl_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -131,39 +242,63 @@ func (x *UploadFileUnaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadFileUnaryResponse.ProtoReflect.Descriptor instead.
-func (*UploadFileUnaryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
 	return file_file_public_fl_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *UploadFileUnaryResponse) GetFileId() string {
+func (x *UploadFileResponse) GetFileId() string {
 	if x != nil {
 		return x.FileId
 	}
 	return ""
 }
 
-type DownloadFileUnaryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+func (x *UploadFileResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadFileResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UploadFileUnaryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	CourseId string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId  string                 `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Content  []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"` // файл целиком
+	MimeType string                 `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	FileId   string                 `protobuf:"bytes,6,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`       // если задан - загружается новая версия этого файла
+	FolderId string                 `protobuf:"bytes,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // если задан - course_id и group_id берутся из папки
+	// Суммы содержимого в hex, необязательны: при несовпадении
+	// с посчитанными сервисом загрузка отклоняется
+	Sha256        string `protobuf:"bytes,8,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Crc32C        string `protobuf:"bytes,9,opt,name=crc32c,proto3" json:"crc32c,omitempty"` // CRC32C (Castagnoli), 4 байта big-endian
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadFileUnaryRequest) Reset() {
-	*x = DownloadFileUnaryRequest{}
+func (x *UploadFileUnaryRequest) Reset() {
+	*x = UploadFileUnaryRequest{}
 	mi := &file_file_public_fl_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadFileUnaryRequest) String() string {
+func (x *UploadFileUnaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadFileUnaryRequest) ProtoMessage() {}
+func (*UploadFileUnaryRequest) ProtoMessage() {}
 
-func (x *DownloadFileUnaryRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadFileUnaryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_file_public_fl_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -175,100 +310,97 @@ func (x *DownloadFileUnaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadFileUnaryRequest.ProtoReflect.Descriptor instead.
-func (*DownloadFileUnaryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileUnaryRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileUnaryRequest) Descriptor() ([]byte, []int) {
 	return file_file_public_fl_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DownloadFileUnaryRequest) GetFileId() string {
+func (x *UploadFileUnaryRequest) GetFilename() string {
 	if x != nil {
-		return x.FileId
+		return x.Filename
 	}
 	return ""
 }
 
-type DownloadFileUnaryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UploadFileUnaryRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
 }
 
-func (x *DownloadFileUnaryResponse) Reset() {
-	*x = DownloadFileUnaryResponse{}
-	mi := &file_file_public_fl_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UploadFileUnaryRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
 }
 
-func (x *DownloadFileUnaryResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UploadFileUnaryRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
 }
 
-func (*DownloadFileUnaryResponse) ProtoMessage() {}
-
-func (x *DownloadFileUnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[3]
+func (x *UploadFileUnaryRequest) GetMimeType() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MimeType
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DownloadFileUnaryResponse.ProtoReflect.Descriptor instead.
-func (*DownloadFileUnaryResponse) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{3}
+func (x *UploadFileUnaryRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
 }
 
-func (x *DownloadFileUnaryResponse) GetContent() []byte {
+func (x *UploadFileUnaryRequest) GetFolderId() string {
 	if x != nil {
-		return x.Content
+		return x.FolderId
 	}
-	return nil
+	return ""
 }
 
-func (x *DownloadFileUnaryResponse) GetFilename() string {
+func (x *UploadFileUnaryRequest) GetSha256() string {
 	if x != nil {
-		return x.Filename
+		return x.Sha256
 	}
 	return ""
 }
 
-func (x *DownloadFileUnaryResponse) GetMimeType() string {
+func (x *UploadFileUnaryRequest) GetCrc32C() string {
 	if x != nil {
-		return x.MimeType
+		return x.Crc32C
 	}
 	return ""
 }
 
-type GetFileRequest struct {
+type UploadFileUnaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFileRequest) Reset() {
-	*x = GetFileRequest{}
-	mi := &file_file_public_fl_proto_msgTypes[4]
+func (x *UploadFileUnaryResponse) Reset() {
+	*x = UploadFileUnaryResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFileRequest) String() string {
+func (x *UploadFileUnaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileRequest) ProtoMessage() {}
+func (*UploadFileUnaryResponse) ProtoMessage() {}
 
-func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[4]
+func (x *UploadFileUnaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,40 +411,49 @@ func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
-func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use UploadFileUnaryResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileUnaryResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetFileRequest) GetFileId() string {
+func (x *UploadFileUnaryResponse) GetFileId() string {
 	if x != nil {
 		return x.FileId
 	}
 	return ""
 }
 
-type DeleteFileRequest struct {
+func (x *UploadFileUnaryResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UploadArchiveMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	FolderId      string                 `protobuf:"bytes,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // если задан - архив распаковывается в эту папку
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileRequest) Reset() {
-	*x = DeleteFileRequest{}
-	mi := &file_file_public_fl_proto_msgTypes[5]
+func (x *UploadArchiveMetadata) Reset() {
+	*x = UploadArchiveMetadata{}
+	mi := &file_file_public_fl_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileRequest) String() string {
+func (x *UploadArchiveMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileRequest) ProtoMessage() {}
+func (*UploadArchiveMetadata) ProtoMessage() {}
 
-func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[5]
+func (x *UploadArchiveMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,40 +464,58 @@ func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
-func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UploadArchiveMetadata.ProtoReflect.Descriptor instead.
+func (*UploadArchiveMetadata) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DeleteFileRequest) GetFileId() string {
+func (x *UploadArchiveMetadata) GetCourseId() string {
 	if x != nil {
-		return x.FileId
+		return x.CourseId
 	}
 	return ""
 }
 
-type DeleteFileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+func (x *UploadArchiveMetadata) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *UploadArchiveMetadata) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type UploadArchiveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadArchiveRequest_Metadata
+	//	*UploadArchiveRequest_Chunk
+	Data          isUploadArchiveRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileResponse) Reset() {
-	*x = DeleteFileResponse{}
-	mi := &file_file_public_fl_proto_msgTypes[6]
+func (x *UploadArchiveRequest) Reset() {
+	*x = UploadArchiveRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileResponse) String() string {
+func (x *UploadArchiveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileResponse) ProtoMessage() {}
+func (*UploadArchiveRequest) ProtoMessage() {}
 
-func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[6]
+func (x *UploadArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,39 +526,152 @@ func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
-func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UploadArchiveRequest.ProtoReflect.Descriptor instead.
+func (*UploadArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteFileResponse) GetSuccess() bool {
+func (x *UploadArchiveRequest) GetData() isUploadArchiveRequest_Data {
 	if x != nil {
-		return x.Success
+		return x.Data
 	}
-	return false
+	return nil
 }
 
-type ListFilesByUserRequest struct {
+func (x *UploadArchiveRequest) GetMetadata() *UploadArchiveMetadata {
+	if x != nil {
+		if x, ok := x.Data.(*UploadArchiveRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadArchiveRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadArchiveRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadArchiveRequest_Data interface {
+	isUploadArchiveRequest_Data()
+}
+
+type UploadArchiveRequest_Metadata struct {
+	Metadata *UploadArchiveMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadArchiveRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadArchiveRequest_Metadata) isUploadArchiveRequest_Data() {}
+
+func (*UploadArchiveRequest_Chunk) isUploadArchiveRequest_Data() {}
+
+// Результат распаковки одной записи архива
+type ArchiveEntryResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                         // путь внутри архива
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`       // пусто у каталогов и при ошибке
+	FolderId      string                 `protobuf:"bytes,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // папка, куда попала запись, пусто - место назначения
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // пусто, если запись распакована
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesByUserRequest) Reset() {
-	*x = ListFilesByUserRequest{}
+func (x *ArchiveEntryResult) Reset() {
+	*x = ArchiveEntryResult{}
+	mi := &file_file_public_fl_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveEntryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveEntryResult) ProtoMessage() {}
+
+func (x *ArchiveEntryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveEntryResult.ProtoReflect.Descriptor instead.
+func (*ArchiveEntryResult) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ArchiveEntryResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ArchiveEntryResult) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ArchiveEntryResult) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *ArchiveEntryResult) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ArchiveEntryResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UploadArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ArchiveEntryResult  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	FilesCreated  int32                  `protobuf:"varint,2,opt,name=files_created,json=filesCreated,proto3" json:"files_created,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadArchiveResponse) Reset() {
+	*x = UploadArchiveResponse{}
 	mi := &file_file_public_fl_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesByUserRequest) String() string {
+func (x *UploadArchiveResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesByUserRequest) ProtoMessage() {}
+func (*UploadArchiveResponse) ProtoMessage() {}
 
-func (x *ListFilesByUserRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadArchiveResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_file_public_fl_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -411,42 +683,4249 @@ func (x *ListFilesByUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesByUserRequest.ProtoReflect.Descriptor instead.
-func (*ListFilesByUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadArchiveResponse.ProtoReflect.Descriptor instead.
+func (*UploadArchiveResponse) Descriptor() ([]byte, []int) {
 	return file_file_public_fl_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListFilesByUserRequest) GetUserId() string {
+func (x *UploadArchiveResponse) GetEntries() []*ArchiveEntryResult {
 	if x != nil {
-		return x.UserId
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-type ListFilesByCourseRequest struct {
+func (x *UploadArchiveResponse) GetFilesCreated() int32 {
+	if x != nil {
+		return x.FilesCreated
+	}
+	return 0
+}
+
+func (x *UploadArchiveResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type CreateUploadSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Metadata      *FileMetadata          `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesByCourseRequest) Reset() {
-	*x = ListFilesByCourseRequest{}
+func (x *CreateUploadSessionRequest) Reset() {
+	*x = CreateUploadSessionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionRequest) ProtoMessage() {}
+
+func (x *CreateUploadSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_file_public_fl_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateUploadSessionRequest) GetMetadata() *FileMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateUploadSessionRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type UploadSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ChunkSize     int64                  `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"` // длина чанка должна быть кратна chunk_size, кроме последнего
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
+	mi := &file_file_public_fl_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSession) ProtoMessage() {}
+
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UploadSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UploadSession) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadSession) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadSession) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UploadSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type UploadChunkHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunkHeader) Reset() {
+	*x = UploadChunkHeader{}
+	mi := &file_file_public_fl_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunkHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunkHeader) ProtoMessage() {}
+
+func (x *UploadChunkHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunkHeader.ProtoReflect.Descriptor instead.
+func (*UploadChunkHeader) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UploadChunkHeader) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UploadChunkHeader) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadChunkHeader) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type WriteUploadSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*WriteUploadSessionRequest_Header
+	//	*WriteUploadSessionRequest_Chunk
+	Data          isWriteUploadSessionRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteUploadSessionRequest) Reset() {
+	*x = WriteUploadSessionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteUploadSessionRequest) ProtoMessage() {}
+
+func (x *WriteUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*WriteUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WriteUploadSessionRequest) GetData() isWriteUploadSessionRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteUploadSessionRequest) GetHeader() *UploadChunkHeader {
+	if x != nil {
+		if x, ok := x.Data.(*WriteUploadSessionRequest_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *WriteUploadSessionRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*WriteUploadSessionRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isWriteUploadSessionRequest_Data interface {
+	isWriteUploadSessionRequest_Data()
+}
+
+type WriteUploadSessionRequest_Header struct {
+	Header *UploadChunkHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type WriteUploadSessionRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*WriteUploadSessionRequest_Header) isWriteUploadSessionRequest_Data() {}
+
+func (*WriteUploadSessionRequest_Chunk) isWriteUploadSessionRequest_Data() {}
+
+type GetUploadSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadSessionRequest) Reset() {
+	*x = GetUploadSessionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadSessionRequest) ProtoMessage() {}
+
+func (x *GetUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUploadSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type FinalizeUploadSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinalizeUploadSessionRequest) Reset() {
+	*x = FinalizeUploadSessionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeUploadSessionRequest) ProtoMessage() {}
+
+func (x *FinalizeUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FinalizeUploadSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type AbortUploadSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadSessionRequest) Reset() {
+	*x = AbortUploadSessionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadSessionRequest) ProtoMessage() {}
+
+func (x *AbortUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AbortUploadSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type AbortUploadSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadSessionResponse) Reset() {
+	*x = AbortUploadSessionResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadSessionResponse) ProtoMessage() {}
+
+func (x *AbortUploadSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadSessionResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadSessionResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AbortUploadSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *FileMetadata          `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadURLRequest) Reset() {
+	*x = CreateUploadURLRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadURLRequest) ProtoMessage() {}
+
+func (x *CreateUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateUploadURLRequest) GetMetadata() *FileMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateUploadURLRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type CreateUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // заголовки, которые нужно передать в PUT
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadURLResponse) Reset() {
+	*x = CreateUploadURLResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadURLResponse) ProtoMessage() {}
+
+func (x *CreateUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateUploadURLResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *CreateUploadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateUploadURLResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *CreateUploadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ConfirmUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmUploadRequest) Reset() {
+	*x = ConfirmUploadRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmUploadRequest) ProtoMessage() {}
+
+func (x *ConfirmUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmUploadRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmUploadRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfirmUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type CreateDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Inline        bool                   `protobuf:"varint,2,opt,name=inline,proto3" json:"inline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadURLRequest) Reset() {
+	*x = CreateDownloadURLRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadURLRequest) ProtoMessage() {}
+
+func (x *CreateDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateDownloadURLRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CreateDownloadURLRequest) GetInline() bool {
+	if x != nil {
+		return x.Inline
+	}
+	return false
+}
+
+type CreateDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadURLResponse) Reset() {
+	*x = CreateDownloadURLResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadURLResponse) ProtoMessage() {}
+
+func (x *CreateDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateDownloadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type DownloadFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"` // 0 - до конца файла
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileRequest) Reset() {
+	*x = DownloadFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileRequest) ProtoMessage() {}
+
+func (x *DownloadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DownloadFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *DownloadFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DownloadFileHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileHeader) Reset() {
+	*x = DownloadFileHeader{}
+	mi := &file_file_public_fl_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileHeader) ProtoMessage() {}
+
+func (x *DownloadFileHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileHeader.ProtoReflect.Descriptor instead.
+func (*DownloadFileHeader) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DownloadFileHeader) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *DownloadFileHeader) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *DownloadFileHeader) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadFileHeader) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DownloadFileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadFileResponse_Header
+	//	*DownloadFileResponse_Chunk
+	Data          isDownloadFileResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileResponse) Reset() {
+	*x = DownloadFileResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileResponse) ProtoMessage() {}
+
+func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DownloadFileResponse) GetData() isDownloadFileResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadFileResponse) GetHeader() *DownloadFileHeader {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadFileResponse_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *DownloadFileResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadFileResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadFileResponse_Data interface {
+	isDownloadFileResponse_Data()
+}
+
+type DownloadFileResponse_Header struct {
+	Header *DownloadFileHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type DownloadFileResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadFileResponse_Header) isDownloadFileResponse_Data() {}
+
+func (*DownloadFileResponse_Chunk) isDownloadFileResponse_Data() {}
+
+type DownloadFileUnaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileUnaryRequest) Reset() {
+	*x = DownloadFileUnaryRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileUnaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileUnaryRequest) ProtoMessage() {}
+
+func (x *DownloadFileUnaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileUnaryRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileUnaryRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DownloadFileUnaryRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DownloadFileUnaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileUnaryResponse) Reset() {
+	*x = DownloadFileUnaryResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileUnaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileUnaryResponse) ProtoMessage() {}
+
+func (x *DownloadFileUnaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileUnaryResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileUnaryResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DownloadFileUnaryResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DownloadFileUnaryResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DownloadFileUnaryResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+type DownloadArchiveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Файлы архива; если пусто - все доступные файлы папки folder_id
+	// с подпапками либо course_id и/или group_id
+	FileIds       []string `protobuf:"bytes,1,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	CourseId      string   `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string   `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	FolderId      string   `protobuf:"bytes,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // пути подпапок сохраняются в архиве
+	Name          string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                         // имя архива без .zip, по умолчанию "files"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadArchiveRequest) Reset() {
+	*x = DownloadArchiveRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArchiveRequest) ProtoMessage() {}
+
+func (x *DownloadArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadArchiveRequest.ProtoReflect.Descriptor instead.
+func (*DownloadArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DownloadArchiveRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *DownloadArchiveRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *DownloadArchiveRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *DownloadArchiveRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *DownloadArchiveRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DownloadArchiveHeader struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // имя архива с .zip
+	FileCount int32                  `protobuf:"varint,2,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	Size      int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"` // суммарный размер файлов до сжатия
+	// Файлы курса или группы, которые не попали в архив:
+	// еще не проверены на вирусы или заражены
+	Skipped       int32 `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadArchiveHeader) Reset() {
+	*x = DownloadArchiveHeader{}
+	mi := &file_file_public_fl_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArchiveHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArchiveHeader) ProtoMessage() {}
+
+func (x *DownloadArchiveHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadArchiveHeader.ProtoReflect.Descriptor instead.
+func (*DownloadArchiveHeader) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DownloadArchiveHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DownloadArchiveHeader) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *DownloadArchiveHeader) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DownloadArchiveHeader) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type DownloadArchiveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadArchiveResponse_Header
+	//	*DownloadArchiveResponse_Chunk
+	Data          isDownloadArchiveResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadArchiveResponse) Reset() {
+	*x = DownloadArchiveResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArchiveResponse) ProtoMessage() {}
+
+func (x *DownloadArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadArchiveResponse.ProtoReflect.Descriptor instead.
+func (*DownloadArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DownloadArchiveResponse) GetData() isDownloadArchiveResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadArchiveResponse) GetHeader() *DownloadArchiveHeader {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadArchiveResponse_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *DownloadArchiveResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadArchiveResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadArchiveResponse_Data interface {
+	isDownloadArchiveResponse_Data()
+}
+
+type DownloadArchiveResponse_Header struct {
+	Header *DownloadArchiveHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type DownloadArchiveResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadArchiveResponse_Header) isDownloadArchiveResponse_Data() {}
+
+func (*DownloadArchiveResponse_Chunk) isDownloadArchiveResponse_Data() {}
+
+type GetFilePreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Content       bool                   `protobuf:"varint,2,opt,name=content,proto3" json:"content,omitempty"` // true - вернуть байты миниатюры вместо ссылки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFilePreviewRequest) Reset() {
+	*x = GetFilePreviewRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFilePreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFilePreviewRequest) ProtoMessage() {}
+
+func (x *GetFilePreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFilePreviewRequest.ProtoReflect.Descriptor instead.
+func (*GetFilePreviewRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetFilePreviewRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetFilePreviewRequest) GetContent() bool {
+	if x != nil {
+		return x.Content
+	}
+	return false
+}
+
+type GetFilePreviewResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MimeType string                 `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Ссылка на миниатюру, если content = false
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFilePreviewResponse) Reset() {
+	*x = GetFilePreviewResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFilePreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFilePreviewResponse) ProtoMessage() {}
+
+func (x *GetFilePreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFilePreviewResponse.ProtoReflect.Descriptor instead.
+func (*GetFilePreviewResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetFilePreviewResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *GetFilePreviewResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetFilePreviewResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *GetFilePreviewResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ListFileVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsRequest) Reset() {
+	*x = ListFileVersionsRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsRequest) ProtoMessage() {}
+
+func (x *ListFileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListFileVersionsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListFileVersionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Versions       []*FileVersion         `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"` // от новых к старым
+	CurrentVersion int32                  `protobuf:"varint,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsResponse) Reset() {
+	*x = ListFileVersionsResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsResponse) ProtoMessage() {}
+
+func (x *ListFileVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListFileVersionsResponse) GetVersions() []*FileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListFileVersionsResponse) GetCurrentVersion() int32 {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return 0
+}
+
+type DownloadFileVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"` // 0 - до конца версии
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileVersionRequest) Reset() {
+	*x = DownloadFileVersionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileVersionRequest) ProtoMessage() {}
+
+func (x *DownloadFileVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileVersionRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileVersionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DownloadFileVersionRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *DownloadFileVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadFileVersionRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadFileVersionRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type RestoreFileVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileVersionRequest) Reset() {
+	*x = RestoreFileVersionRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileVersionRequest) ProtoMessage() {}
+
+func (x *RestoreFileVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileVersionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFileVersionRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RestoreFileVersionRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RestoreFileVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileRequest) Reset() {
+	*x = GetFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileRequest) ProtoMessage() {}
+
+func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteFileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UpdateFileMetadataRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Пустые поля не меняются
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Тип определяется по содержимому и имени, mime_type только сверяется с ним
+	MimeType      string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileMetadataRequest) Reset() {
+	*x = UpdateFileMetadataRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateFileMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFileMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateFileMetadataRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UpdateFileMetadataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFileMetadataRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+// Назначение: folder_id, а без него - корень course_id и group_id
+type MoveFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FolderId      string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	CourseId      string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFileRequest) Reset() {
+	*x = MoveFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileRequest) ProtoMessage() {}
+
+func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
+func (*MoveFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *MoveFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type CopyFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FolderId      string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	CourseId      string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"` // пусто - имя исходного файла
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyFileRequest) Reset() {
+	*x = CopyFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileRequest) ProtoMessage() {}
+
+func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileRequest.ProtoReflect.Descriptor instead.
+func (*CopyFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CopyFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AddFileTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // приводятся к нижнему регистру
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFileTagsRequest) Reset() {
+	*x = AddFileTagsRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFileTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFileTagsRequest) ProtoMessage() {}
+
+func (x *AddFileTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFileTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddFileTagsRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddFileTagsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *AddFileTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveFileTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFileTagsRequest) Reset() {
+	*x = RemoveFileTagsRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFileTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileTagsRequest) ProtoMessage() {}
+
+func (x *RemoveFileTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFileTagsRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RemoveFileTagsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RemoveFileTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SetFileMetadataRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Пары для записи, пустое значение удаляет ключ
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// true - удалить ключи, которых нет в metadata
+	Replace       bool `protobuf:"varint,3,opt,name=replace,proto3" json:"replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFileMetadataRequest) Reset() {
+	*x = SetFileMetadataRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFileMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFileMetadataRequest) ProtoMessage() {}
+
+func (x *SetFileMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFileMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetFileMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetFileMetadataRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *SetFileMetadataRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SetFileMetadataRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+type ListFilesByUserRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"` // с 1
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy   FileSortField          `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=file.FileSortField" json:"sort_by,omitempty"`
+	Desc     bool                   `protobuf:"varint,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	// Фильтры, пустые значения не применяются
+	MimeType    string                 `protobuf:"bytes,6,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UploaderId  string                 `protobuf:"bytes,7,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Файл должен иметь все теги и все пары метаданных,
+	// в query string: ?tags=a&tags=b&metadata[key]=value
+	Tags          []string          `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesByUserRequest) Reset() {
+	*x = ListFilesByUserRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesByUserRequest) ProtoMessage() {}
+
+func (x *ListFilesByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesByUserRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListFilesByUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFilesByUserRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFilesByUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFilesByUserRequest) GetSortBy() FileSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return FileSortField_FILE_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListFilesByUserRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListFilesByUserRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ListFilesByUserRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ListFilesByUserRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListFilesByUserRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListFilesByUserRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListFilesByUserRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListFilesByCourseRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"` // с 1
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy   FileSortField          `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=file.FileSortField" json:"sort_by,omitempty"`
+	Desc     bool                   `protobuf:"varint,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	// Фильтры, пустые значения не применяются
+	MimeType    string                 `protobuf:"bytes,6,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UploaderId  string                 `protobuf:"bytes,7,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Файл должен иметь все теги и все пары метаданных,
+	// в query string: ?tags=a&tags=b&metadata[key]=value
+	Tags          []string          `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesByCourseRequest) Reset() {
+	*x = ListFilesByCourseRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesByCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesByCourseRequest) ProtoMessage() {}
+
+func (x *ListFilesByCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesByCourseRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesByCourseRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListFilesByCourseRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ListFilesByCourseRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFilesByCourseRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFilesByCourseRequest) GetSortBy() FileSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return FileSortField_FILE_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListFilesByCourseRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListFilesByCourseRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ListFilesByCourseRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ListFilesByCourseRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListFilesByCourseRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListFilesByCourseRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListFilesByCourseRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListFilesByGroupRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GroupId  string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"` // с 1
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy   FileSortField          `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=file.FileSortField" json:"sort_by,omitempty"`
+	Desc     bool                   `protobuf:"varint,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	// Фильтры, пустые значения не применяются
+	MimeType    string                 `protobuf:"bytes,6,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UploaderId  string                 `protobuf:"bytes,7,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Файл должен иметь все теги и все пары метаданных,
+	// в query string: ?tags=a&tags=b&metadata[key]=value
+	Tags          []string          `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesByGroupRequest) Reset() {
+	*x = ListFilesByGroupRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesByGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesByGroupRequest) ProtoMessage() {}
+
+func (x *ListFilesByGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesByGroupRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesByGroupRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListFilesByGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListFilesByGroupRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFilesByGroupRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFilesByGroupRequest) GetSortBy() FileSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return FileSortField_FILE_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListFilesByGroupRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListFilesByGroupRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ListFilesByGroupRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ListFilesByGroupRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListFilesByGroupRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListFilesByGroupRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListFilesByGroupRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*File                `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesResponse) Reset() {
+	*x = ListFilesResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesResponse) ProtoMessage() {}
+
+func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListFilesResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListFilesResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFilesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SearchFilesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Синтаксис как у поисковиков: слова, "фраза", -исключение, or
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Необязательные фильтры
+	CourseId      string `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	MimeType      string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Page          int32  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"` // с 1
+	PageSize      int32  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFilesRequest) Reset() {
+	*x = SearchFilesRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFilesRequest) ProtoMessage() {}
+
+func (x *SearchFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFilesRequest.ProtoReflect.Descriptor instead.
+func (*SearchFilesRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SearchFilesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchFilesRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *SearchFilesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *SearchFilesRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *SearchFilesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchFilesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchHit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	File  *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Rank  float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// HTML-экранированный текст, совпадения обрамлены <mark>
+	NameHighlight string `protobuf:"bytes,3,opt,name=name_highlight,json=nameHighlight,proto3" json:"name_highlight,omitempty"`
+	Snippet       string `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_file_public_fl_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SearchHit) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *SearchHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchHit) GetNameHighlight() string {
+	if x != nil {
+		return x.NameHighlight
+	}
+	return ""
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFilesResponse) Reset() {
+	*x = SearchFilesResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFilesResponse) ProtoMessage() {}
+
+func (x *SearchFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFilesResponse.ProtoReflect.Descriptor instead.
+func (*SearchFilesResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SearchFilesResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchFilesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListTrashRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"` // пусто - собственные файлы вызывающего
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                        // с 1
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy   FileSortField          `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=file.FileSortField" json:"sort_by,omitempty"`
+	Desc     bool                   `protobuf:"varint,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	// Фильтры, пустые значения не применяются
+	MimeType    string                 `protobuf:"bytes,6,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UploaderId  string                 `protobuf:"bytes,7,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Файл должен иметь все теги и все пары метаданных,
+	// в query string: ?tags=a&tags=b&metadata[key]=value
+	Tags          []string          `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListTrashRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetSortBy() FileSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return FileSortField_FILE_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListTrashRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListTrashRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTrashRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListTrashRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTrashRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RestoreFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileRequest) Reset() {
+	*x = RestoreFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileRequest) ProtoMessage() {}
+
+func (x *RestoreFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RestoreFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type PurgeFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeFileRequest) Reset() {
+	*x = PurgeFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeFileRequest) ProtoMessage() {}
+
+func (x *PurgeFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeFileRequest.ProtoReflect.Descriptor instead.
+func (*PurgeFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PurgeFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type PurgeFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeFileResponse) Reset() {
+	*x = PurgeFileResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeFileResponse) ProtoMessage() {}
+
+func (x *PurgeFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeFileResponse.ProtoReflect.Descriptor instead.
+func (*PurgeFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PurgeFileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateFolderRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // пусто - корень курса или группы
+	// Игнорируются, если задан parent_id
+	CourseId      string `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderRequest) Reset() {
+	*x = CreateFolderRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderRequest) ProtoMessage() {}
+
+func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CreateFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type RenameFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameFolderRequest) Reset() {
+	*x = RenameFolderRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameFolderRequest) ProtoMessage() {}
+
+func (x *RenameFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameFolderRequest.ProtoReflect.Descriptor instead.
+func (*RenameFolderRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RenameFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *RenameFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MoveFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // пусто - в корень курса или группы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderRequest) Reset() {
+	*x = MoveFolderRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderRequest) ProtoMessage() {}
+
+func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderRequest.ProtoReflect.Descriptor instead.
+func (*MoveFolderRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *MoveFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type DeleteFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderRequest) Reset() {
+	*x = DeleteFolderRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderRequest) ProtoMessage() {}
+
+func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DeleteFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type DeleteFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	FilesDeleted  int32                  `protobuf:"varint,2,opt,name=files_deleted,json=filesDeleted,proto3" json:"files_deleted,omitempty"` // перенесено в корзину
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderResponse) Reset() {
+	*x = DeleteFolderResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderResponse) ProtoMessage() {}
+
+func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteFolderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteFolderResponse) GetFilesDeleted() int32 {
+	if x != nil {
+		return x.FilesDeleted
+	}
+	return 0
+}
+
+type ListFolderRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FolderId string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // пусто - корень course_id или group_id
+	CourseId string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId  string                 `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// Страница и фильтры относятся к файлам, подпапки возвращаются все
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"` // с 1
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	SortBy        FileSortField          `protobuf:"varint,6,opt,name=sort_by,json=sortBy,proto3,enum=file.FileSortField" json:"sort_by,omitempty"`
+	Desc          bool                   `protobuf:"varint,7,opt,name=desc,proto3" json:"desc,omitempty"`
+	MimeType      string                 `protobuf:"bytes,8,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UploaderId    string                 `protobuf:"bytes,9,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Tags          []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderRequest) Reset() {
+	*x = ListFolderRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderRequest) ProtoMessage() {}
+
+func (x *ListFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderRequest.ProtoReflect.Descriptor instead.
+func (*ListFolderRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFolderRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFolderRequest) GetSortBy() FileSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return FileSortField_FILE_SORT_FIELD_CREATED_AT
+}
+
+func (x *ListFolderRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListFolderRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListFolderRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListFolderRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListFolderRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"` // не задано для корня
+	Folders       []*Folder              `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders,omitempty"`
+	Files         []*File                `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // всего файлов
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderResponse) Reset() {
+	*x = ListFolderResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderResponse) ProtoMessage() {}
+
+func (x *ListFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderResponse.ProtoReflect.Descriptor instead.
+func (*ListFolderResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *ListFolderResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+func (x *ListFolderResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFolderResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateShareLinkRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Все ограничения необязательны
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	MaxDownloads  int32                  `protobuf:"varint,4,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"` // 0 - без ограничения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateShareLinkRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+type ListShareLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListShareLinksRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ShareLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkId        string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RevokeShareLinkRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+type RevokeShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkResponse) ProtoMessage() {}
+
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RevokeShareLinkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetSharedFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedFileRequest) Reset() {
+	*x = GetSharedFileRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedFileRequest) ProtoMessage() {}
+
+func (x *GetSharedFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedFileRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedFileRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetSharedFileRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetSharedFileRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DownloadSharedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"` // 0 - до конца файла
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadSharedRequest) Reset() {
+	*x = DownloadSharedRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadSharedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadSharedRequest) ProtoMessage() {}
+
+func (x *DownloadSharedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadSharedRequest.ProtoReflect.Descriptor instead.
+func (*DownloadSharedRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DownloadSharedRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DownloadSharedRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DownloadSharedRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadSharedRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // пусто - вызывающий
+	CourseId      string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"` // пусто - без данных по курсу
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_file_public_fl_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+type Usage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Used          int64                  `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 - без ограничения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_file_public_fl_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Usage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *Usage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *Usage                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Course        *Usage                 `protobuf:"bytes,2,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_file_public_fl_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetUsageResponse) GetUser() *Usage {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetCourse() *Usage {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+type File struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MimeType       string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size           int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	UploaderId     string                 `protobuf:"bytes,5,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CourseId       string                 `protobuf:"bytes,6,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId        string                 `protobuf:"bytes,7,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Sha256         string                 `protobuf:"bytes,9,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Version        int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                     // текущая версия
+	DeletedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // задано для файлов в корзине
+	FolderId       string                 `protobuf:"bytes,12,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`    // пусто - корень курса или группы
+	ScanStatus     ScanStatus             `protobuf:"varint,13,opt,name=scan_status,json=scanStatus,proto3,enum=file.ScanStatus" json:"scan_status,omitempty"`
+	ScanSignature  string                 `protobuf:"bytes,14,opt,name=scan_signature,json=scanSignature,proto3" json:"scan_signature,omitempty"` // задано для SCAN_STATUS_INFECTED
+	HasPreview     bool                   `protobuf:"varint,15,opt,name=has_preview,json=hasPreview,proto3" json:"has_preview,omitempty"`
+	Tags           []string               `protobuf:"bytes,16,rep,name=tags,proto3" json:"tags,omitempty"` // по алфавиту
+	Metadata       map[string]string      `protobuf:"bytes,17,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ContentMissing bool                   `protobuf:"varint,18,opt,name=content_missing,json=contentMissing,proto3" json:"content_missing,omitempty"` // содержимое пропало из хранилища, скачать нельзя
+	Crc32C         string                 `protobuf:"bytes,19,opt,name=crc32c,proto3" json:"crc32c,omitempty"`                                        // CRC32C в hex, пусто, пока содержимое не перепроверено
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	mi := &file_file_public_fl_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *File) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *File) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *File) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *File) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *File) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *File) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *File) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *File) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *File) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *File) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *File) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *File) GetScanStatus() ScanStatus {
+	if x != nil {
+		return x.ScanStatus
+	}
+	return ScanStatus_SCAN_STATUS_PENDING
+}
+
+func (x *File) GetScanSignature() string {
+	if x != nil {
+		return x.ScanSignature
+	}
+	return ""
+}
+
+func (x *File) GetHasPreview() bool {
+	if x != nil {
+		return x.HasPreview
+	}
+	return false
+}
+
+func (x *File) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *File) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *File) GetContentMissing() bool {
+	if x != nil {
+		return x.ContentMissing
+	}
+	return false
+}
+
+func (x *File) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+type ShareLink struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Only returned by CreateShareLink: the service keeps a hash of the token
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	FileId        string                 `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	HasPassword   bool                   `protobuf:"varint,5,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	MaxDownloads  int32                  `protobuf:"varint,6,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	Downloads     int32                  `protobuf:"varint,7,opt,name=downloads,proto3" json:"downloads,omitempty"`
+	CreatorId     string                 `protobuf:"bytes,8,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_file_public_fl_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesByCourseRequest) String() string {
+func (x *ShareLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesByCourseRequest) ProtoMessage() {}
+func (*ShareLink) ProtoMessage() {}
 
-func (x *ListFilesByCourseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[8]
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,56 +4936,102 @@ func (x *ListFilesByCourseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesByCourseRequest.ProtoReflect.Descriptor instead.
-func (*ListFilesByCourseRequest) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *ListFilesByCourseRequest) GetCourseId() string {
+func (x *ShareLink) GetId() string {
 	if x != nil {
-		return x.CourseId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListFilesByCourseRequest) GetPage() int32 {
+func (x *ShareLink) GetToken() string {
 	if x != nil {
-		return x.Page
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ShareLink) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareLink) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.MaxDownloads
 	}
 	return 0
 }
 
-func (x *ListFilesByCourseRequest) GetPageSize() int32 {
+func (x *ShareLink) GetDownloads() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.Downloads
 	}
 	return 0
 }
 
-type ListFilesByGroupRequest struct {
+func (x *ShareLink) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Folder struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	CourseId      string                 `protobuf:"bytes,4,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,5,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	CreatorId     string                 `protobuf:"bytes,6,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesByGroupRequest) Reset() {
-	*x = ListFilesByGroupRequest{}
-	mi := &file_file_public_fl_proto_msgTypes[9]
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_file_public_fl_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesByGroupRequest) String() string {
+func (x *Folder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesByGroupRequest) ProtoMessage() {}
+func (*Folder) ProtoMessage() {}
 
-func (x *ListFilesByGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[9]
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,113 +5042,92 @@ func (x *ListFilesByGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesByGroupRequest.ProtoReflect.Descriptor instead.
-func (*ListFilesByGroupRequest) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *ListFilesByGroupRequest) GetGroupId() string {
+func (x *Folder) GetId() string {
 	if x != nil {
-		return x.GroupId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListFilesByGroupRequest) GetPage() int32 {
+func (x *Folder) GetName() string {
 	if x != nil {
-		return x.Page
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListFilesByGroupRequest) GetPageSize() int32 {
+func (x *Folder) GetParentId() string {
 	if x != nil {
-		return x.PageSize
+		return x.ParentId
 	}
-	return 0
-}
-
-type ListFilesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []*File                `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListFilesResponse) Reset() {
-	*x = ListFilesResponse{}
-	mi := &file_file_public_fl_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListFilesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*ListFilesResponse) ProtoMessage() {}
-
-func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[10]
+func (x *Folder) GetCourseId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CourseId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
-func (*ListFilesResponse) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{10}
+func (x *Folder) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
 }
 
-func (x *ListFilesResponse) GetFiles() []*File {
+func (x *Folder) GetCreatorId() string {
 	if x != nil {
-		return x.Files
+		return x.CreatorId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListFilesResponse) GetTotal() int32 {
+func (x *Folder) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Total
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-type File struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	UploaderId    string                 `protobuf:"bytes,5,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
-	CourseId      string                 `protobuf:"bytes,6,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
-	GroupId       string                 `protobuf:"bytes,7,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type FileVersion struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Version    int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	MimeType   string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size       int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256     string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	UploaderId string                 `protobuf:"bytes,5,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Отличия от предыдущей версии
+	SizeDelta      int64      `protobuf:"varint,7,opt,name=size_delta,json=sizeDelta,proto3" json:"size_delta,omitempty"`
+	ContentChanged bool       `protobuf:"varint,8,opt,name=content_changed,json=contentChanged,proto3" json:"content_changed,omitempty"`
+	ScanStatus     ScanStatus `protobuf:"varint,9,opt,name=scan_status,json=scanStatus,proto3,enum=file.ScanStatus" json:"scan_status,omitempty"`
+	Crc32C         string     `protobuf:"bytes,10,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *File) Reset() {
-	*x = File{}
-	mi := &file_file_public_fl_proto_msgTypes[11]
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_file_public_fl_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *File) String() string {
+func (x *FileVersion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*File) ProtoMessage() {}
+func (*FileVersion) ProtoMessage() {}
 
-func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[11]
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_file_public_fl_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,80 +5138,108 @@ func (x *File) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use File.ProtoReflect.Descriptor instead.
-func (*File) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use FileVersion.ProtoReflect.Descriptor instead.
+func (*FileVersion) Descriptor() ([]byte, []int) {
+	return file_file_public_fl_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *File) GetId() string {
+func (x *FileVersion) GetVersion() int32 {
 	if x != nil {
-		return x.Id
+		return x.Version
 	}
-	return ""
+	return 0
 }
 
-func (x *File) GetName() string {
+func (x *FileVersion) GetMimeType() string {
 	if x != nil {
-		return x.Name
+		return x.MimeType
 	}
 	return ""
 }
 
-func (x *File) GetMimeType() string {
+func (x *FileVersion) GetSize() int64 {
 	if x != nil {
-		return x.MimeType
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *File) GetSize() int64 {
+func (x *FileVersion) GetSha256() string {
 	if x != nil {
-		return x.Size
+		return x.Sha256
 	}
-	return 0
+	return ""
 }
 
-func (x *File) GetUploaderId() string {
+func (x *FileVersion) GetUploaderId() string {
 	if x != nil {
 		return x.UploaderId
 	}
 	return ""
 }
 
-func (x *File) GetCourseId() string {
+func (x *FileVersion) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CourseId
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *File) GetGroupId() string {
+func (x *FileVersion) GetSizeDelta() int64 {
 	if x != nil {
-		return x.GroupId
+		return x.SizeDelta
 	}
-	return ""
+	return 0
 }
 
-func (x *File) GetCreatedAt() *timestamppb.Timestamp {
+func (x *FileVersion) GetContentChanged() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.ContentChanged
 	}
-	return nil
+	return false
+}
+
+func (x *FileVersion) GetScanStatus() ScanStatus {
+	if x != nil {
+		return x.ScanStatus
+	}
+	return ScanStatus_SCAN_STATUS_PENDING
+}
+
+func (x *FileVersion) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
 }
 
 type FileMetadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	MimeType      string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	CourseId      string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
-	GroupId       string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	MimeType string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	CourseId string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId  string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// SHA-256 содержимого в hex. Если такое содержимое уже хранится,
+	// чанки можно не отправлять
+	Sha256 string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// Если задан - загружается новая версия этого файла,
+	// course_id, group_id и folder_id игнорируются
+	FileId string `protobuf:"bytes,6,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Если задан - файл кладется в папку, course_id и group_id берутся из нее
+	FolderId string `protobuf:"bytes,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// CRC32C (Castagnoli) содержимого в hex, 4 байта big-endian.
+	// Как и sha256, сверяется с посчитанной сервисом суммой
+	Crc32C string `protobuf:"bytes,8,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// Размер содержимого, если известен заранее: по нему квота
+	// проверяется до приема чанков, а загрузка другого размера отклоняется
+	Size          int64 `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FileMetadata) Reset() {
 	*x = FileMetadata{}
-	mi := &file_file_public_fl_proto_msgTypes[12]
+	mi := &file_file_public_fl_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -719,7 +5251,7 @@ func (x *FileMetadata) String() string {
 func (*FileMetadata) ProtoMessage() {}
 
 func (x *FileMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_file_public_fl_proto_msgTypes[12]
+	mi := &file_file_public_fl_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +5264,7 @@ func (x *FileMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileMetadata.ProtoReflect.Descriptor instead.
 func (*FileMetadata) Descriptor() ([]byte, []int) {
-	return file_file_public_fl_proto_rawDescGZIP(), []int{12}
+	return file_file_public_fl_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *FileMetadata) GetFilename() string {
@@ -763,45 +5295,400 @@ func (x *FileMetadata) GetGroupId() string {
 	return ""
 }
 
+func (x *FileMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileMetadata) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *FileMetadata) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *FileMetadata) GetCrc32C() string {
+	if x != nil {
+		return x.Crc32C
+	}
+	return ""
+}
+
+func (x *FileMetadata) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 var File_file_public_fl_proto protoreflect.FileDescriptor
 
 const file_file_public_fl_proto_rawDesc = "" +
 	"\n" +
-	"\x14file/public_fl.proto\x12\x04file\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xa3\x01\n" +
+	"\x14file/public_fl.proto\x12\x04file\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"e\n" +
+	"\x11UploadFileRequest\x120\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.file.FileMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"[\n" +
+	"\x12UploadFileResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"\x89\x02\n" +
 	"\x16UploadFileUnaryRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
 	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x19\n" +
 	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x18\n" +
 	"\acontent\x18\x04 \x01(\fR\acontent\x12\x1b\n" +
-	"\tmime_type\x18\x05 \x01(\tR\bmimeType\"2\n" +
+	"\tmime_type\x18\x05 \x01(\tR\bmimeType\x12\x17\n" +
+	"\afile_id\x18\x06 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\a \x01(\tR\bfolderId\x12\x16\n" +
+	"\x06sha256\x18\b \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06crc32c\x18\t \x01(\tR\x06crc32c\"L\n" +
 	"\x17UploadFileUnaryResponse\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\"3\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"l\n" +
+	"\x15UploadArchiveMetadata\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\tR\bfolderId\"q\n" +
+	"\x14UploadArchiveRequest\x129\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1b.file.UploadArchiveMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x88\x01\n" +
+	"\x12ArchiveEntryResult\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x88\x01\n" +
+	"\x15UploadArchiveResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.file.ArchiveEntryResultR\aentries\x12#\n" +
+	"\rfiles_created\x18\x02 \x01(\x05R\ffilesCreated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"`\n" +
+	"\x1aCreateUploadSessionRequest\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.file.FileMetadataR\bmetadata\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\xa5\x01\n" +
+	"\rUploadSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x04 \x01(\x03R\tchunkSize\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"b\n" +
+	"\x11UploadChunkHeader\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"n\n" +
+	"\x19WriteUploadSessionRequest\x121\n" +
+	"\x06header\x18\x01 \x01(\v2\x17.file.UploadChunkHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"8\n" +
+	"\x17GetUploadSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"=\n" +
+	"\x1cFinalizeUploadSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\":\n" +
+	"\x19AbortUploadSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"6\n" +
+	"\x1aAbortUploadSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\\\n" +
+	"\x16CreateUploadURLRequest\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.file.FileMetadataR\bmetadata\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\x85\x02\n" +
+	"\x17CreateUploadURLResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12D\n" +
+	"\aheaders\x18\x03 \x03(\v2*.file.CreateUploadURLResponse.HeadersEntryR\aheaders\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"3\n" +
+	"\x14ConfirmUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"K\n" +
+	"\x18CreateDownloadURLRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06inline\x18\x02 \x01(\bR\x06inline\"h\n" +
+	"\x19CreateDownloadURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"^\n" +
+	"\x13DownloadFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"x\n" +
+	"\x12DownloadFileHeader\x12\x1e\n" +
+	"\x04file\x18\x01 \x01(\v2\n" +
+	".file.FileR\x04file\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x03R\x06length\"j\n" +
+	"\x14DownloadFileResponse\x122\n" +
+	"\x06header\x18\x01 \x01(\v2\x18.file.DownloadFileHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"3\n" +
 	"\x18DownloadFileUnaryRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\"n\n" +
 	"\x19DownloadFileUnaryResponse\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\fR\acontent\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1b\n" +
-	"\tmime_type\x18\x03 \x01(\tR\bmimeType\")\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\"\x9c\x01\n" +
+	"\x16DownloadArchiveRequest\x12\x19\n" +
+	"\bfile_ids\x18\x01 \x03(\tR\afileIds\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tfolder_id\x18\x05 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\"x\n" +
+	"\x15DownloadArchiveHeader\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x02 \x01(\x05R\tfileCount\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\"p\n" +
+	"\x17DownloadArchiveResponse\x125\n" +
+	"\x06header\x18\x01 \x01(\v2\x1b.file.DownloadArchiveHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"J\n" +
+	"\x15GetFilePreviewRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\bR\acontent\"\x9c\x01\n" +
+	"\x16GetFilePreviewResponse\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\fR\acontent\"2\n" +
+	"\x17ListFileVersionsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"r\n" +
+	"\x18ListFileVersionsResponse\x12-\n" +
+	"\bversions\x18\x01 \x03(\v2\x11.file.FileVersionR\bversions\x12'\n" +
+	"\x0fcurrent_version\x18\x02 \x01(\x05R\x0ecurrentVersion\"\x7f\n" +
+	"\x1aDownloadFileVersionRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x03R\x06length\"N\n" +
+	"\x19RestoreFileVersionRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\")\n" +
 	"\x0eGetFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\",\n" +
 	"\x11DeleteFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\".\n" +
 	"\x12DeleteFileResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"1\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"e\n" +
+	"\x19UpdateFileMetadataRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\"\x7f\n" +
+	"\x0fMoveFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\"\x93\x01\n" +
+	"\x0fCopyFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"A\n" +
+	"\x12AddFileTagsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"D\n" +
+	"\x15RemoveFileTagsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"\xd0\x01\n" +
+	"\x16SetFileMetadataRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12F\n" +
+	"\bmetadata\x18\x02 \x03(\v2*.file.SetFileMetadataRequest.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\areplace\x18\x03 \x01(\bR\areplace\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf5\x03\n" +
 	"\x16ListFilesByUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"h\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12,\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x13.file.FileSortFieldR\x06sortBy\x12\x12\n" +
+	"\x04desc\x18\x05 \x01(\bR\x04desc\x12\x1b\n" +
+	"\tmime_type\x18\x06 \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vuploader_id\x18\a \x01(\tR\n" +
+	"uploaderId\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12F\n" +
+	"\bmetadata\x18\v \x03(\v2*.file.ListFilesByUserRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfd\x03\n" +
 	"\x18ListFilesByCourseRequest\x12\x1b\n" +
 	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"e\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12,\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x13.file.FileSortFieldR\x06sortBy\x12\x12\n" +
+	"\x04desc\x18\x05 \x01(\bR\x04desc\x12\x1b\n" +
+	"\tmime_type\x18\x06 \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vuploader_id\x18\a \x01(\tR\n" +
+	"uploaderId\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12H\n" +
+	"\bmetadata\x18\v \x03(\v2,.file.ListFilesByCourseRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf9\x03\n" +
 	"\x17ListFilesByGroupRequest\x12\x19\n" +
 	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"K\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12,\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x13.file.FileSortFieldR\x06sortBy\x12\x12\n" +
+	"\x04desc\x18\x05 \x01(\bR\x04desc\x12\x1b\n" +
+	"\tmime_type\x18\x06 \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vuploader_id\x18\a \x01(\tR\n" +
+	"uploaderId\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12G\n" +
+	"\bmetadata\x18\v \x03(\v2+.file.ListFilesByGroupRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
 	"\x11ListFilesResponse\x12 \n" +
 	"\x05files\x18\x01 \x03(\v2\n" +
 	".file.FileR\x05files\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xef\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xb0\x01\n" +
+	"\x12SearchFilesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\x80\x01\n" +
+	"\tSearchHit\x12\x1e\n" +
+	"\x04file\x18\x01 \x01(\v2\n" +
+	".file.FileR\x04file\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12%\n" +
+	"\x0ename_highlight\x18\x03 \x01(\tR\rnameHighlight\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\"P\n" +
+	"\x13SearchFilesResponse\x12#\n" +
+	"\x04hits\x18\x01 \x03(\v2\x0f.file.SearchHitR\x04hits\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xed\x03\n" +
+	"\x10ListTrashRequest\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12,\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\x13.file.FileSortFieldR\x06sortBy\x12\x12\n" +
+	"\x04desc\x18\x05 \x01(\bR\x04desc\x12\x1b\n" +
+	"\tmime_type\x18\x06 \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vuploader_id\x18\a \x01(\tR\n" +
+	"uploaderId\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12@\n" +
+	"\bmetadata\x18\v \x03(\v2$.file.ListTrashRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"-\n" +
+	"\x12RestoreFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"+\n" +
+	"\x10PurgeFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"-\n" +
+	"\x11PurgeFileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"~\n" +
+	"\x13CreateFolderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\"F\n" +
+	"\x13RenameFolderRequest\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"M\n" +
+	"\x11MoveFolderRequest\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"2\n" +
+	"\x13DeleteFolderRequest\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\"U\n" +
+	"\x14DeleteFolderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rfiles_deleted\x18\x02 \x01(\x05R\ffilesDeleted\"\xa7\x04\n" +
+	"\x11ListFolderRequest\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12,\n" +
+	"\asort_by\x18\x06 \x01(\x0e2\x13.file.FileSortFieldR\x06sortBy\x12\x12\n" +
+	"\x04desc\x18\a \x01(\bR\x04desc\x12\x1b\n" +
+	"\tmime_type\x18\b \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vuploader_id\x18\t \x01(\tR\n" +
+	"uploaderId\x12=\n" +
+	"\fcreated_from\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12A\n" +
+	"\bmetadata\x18\r \x03(\v2%.file.ListFolderRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9a\x01\n" +
+	"\x12ListFolderResponse\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12&\n" +
+	"\afolders\x18\x02 \x03(\v2\f.file.FolderR\afolders\x12 \n" +
+	"\x05files\x18\x03 \x03(\v2\n" +
+	".file.FileR\x05files\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xad\x01\n" +
+	"\x16CreateShareLinkRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12#\n" +
+	"\rmax_downloads\x18\x04 \x01(\x05R\fmaxDownloads\"0\n" +
+	"\x15ListShareLinksRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\"?\n" +
+	"\x16ListShareLinksResponse\x12%\n" +
+	"\x05links\x18\x01 \x03(\v2\x0f.file.ShareLinkR\x05links\"1\n" +
+	"\x16RevokeShareLinkRequest\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\"3\n" +
+	"\x17RevokeShareLinkResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"H\n" +
+	"\x14GetSharedFileRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"y\n" +
+	"\x15DownloadSharedRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x03R\x06length\"G\n" +
+	"\x0fGetUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\"1\n" +
+	"\x05Usage\x12\x12\n" +
+	"\x04used\x18\x01 \x01(\x03R\x04used\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"X\n" +
+	"\x10GetUsageResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.file.UsageR\x04user\x12#\n" +
+	"\x06course\x18\x02 \x01(\v2\v.file.UsageR\x06course\"\xbc\x05\n" +
 	"\x04File\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
@@ -812,22 +5699,144 @@ const file_file_public_fl_proto_rawDesc = "" +
 	"\tcourse_id\x18\x06 \x01(\tR\bcourseId\x12\x19\n" +
 	"\bgroup_id\x18\a \x01(\tR\agroupId\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x7f\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06sha256\x18\t \x01(\tR\x06sha256\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"deleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1b\n" +
+	"\tfolder_id\x18\f \x01(\tR\bfolderId\x121\n" +
+	"\vscan_status\x18\r \x01(\x0e2\x10.file.ScanStatusR\n" +
+	"scanStatus\x12%\n" +
+	"\x0escan_signature\x18\x0e \x01(\tR\rscanSignature\x12\x1f\n" +
+	"\vhas_preview\x18\x0f \x01(\bR\n" +
+	"hasPreview\x12\x12\n" +
+	"\x04tags\x18\x10 \x03(\tR\x04tags\x124\n" +
+	"\bmetadata\x18\x11 \x03(\v2\x18.file.File.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fcontent_missing\x18\x12 \x01(\bR\x0econtentMissing\x12\x16\n" +
+	"\x06crc32c\x18\x13 \x01(\tR\x06crc32c\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x02\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x17\n" +
+	"\afile_id\x18\x03 \x01(\tR\x06fileId\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12!\n" +
+	"\fhas_password\x18\x05 \x01(\bR\vhasPassword\x12#\n" +
+	"\rmax_downloads\x18\x06 \x01(\x05R\fmaxDownloads\x12\x1c\n" +
+	"\tdownloads\x18\a \x01(\x05R\tdownloads\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\b \x01(\tR\tcreatorId\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdb\x01\n" +
+	"\x06Folder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x1b\n" +
+	"\tcourse_id\x18\x04 \x01(\tR\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x05 \x01(\tR\agroupId\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x06 \x01(\tR\tcreatorId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdf\x02\n" +
+	"\vFileVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x1f\n" +
+	"\vuploader_id\x18\x05 \x01(\tR\n" +
+	"uploaderId\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"size_delta\x18\a \x01(\x03R\tsizeDelta\x12'\n" +
+	"\x0fcontent_changed\x18\b \x01(\bR\x0econtentChanged\x121\n" +
+	"\vscan_status\x18\t \x01(\x0e2\x10.file.ScanStatusR\n" +
+	"scanStatus\x12\x16\n" +
+	"\x06crc32c\x18\n" +
+	" \x01(\tR\x06crc32c\"\xf9\x01\n" +
 	"\fFileMetadata\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
 	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x1b\n" +
 	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12\x19\n" +
-	"\bgroup_id\x18\x04 \x01(\tR\agroupId2\xd4\x05\n" +
-	"\vFileService\x12h\n" +
-	"\x0fUploadFileUnary\x12\x1c.file.UploadFileUnaryRequest\x1a\x1d.file.UploadFileUnaryResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/files/upload\x12r\n" +
-	"\x11DownloadFileUnary\x12\x1e.file.DownloadFileUnaryRequest\x1a\x1f.file.DownloadFileUnaryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/files/get/{file_id}\x12E\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x17\n" +
+	"\afile_id\x18\x06 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\a \x01(\tR\bfolderId\x12\x16\n" +
+	"\x06crc32c\x18\b \x01(\tR\x06crc32c\x12\x12\n" +
+	"\x04size\x18\t \x01(\x03R\x04size*c\n" +
+	"\rFileSortField\x12\x1e\n" +
+	"\x1aFILE_SORT_FIELD_CREATED_AT\x10\x00\x12\x18\n" +
+	"\x14FILE_SORT_FIELD_NAME\x10\x01\x12\x18\n" +
+	"\x14FILE_SORT_FIELD_SIZE\x10\x02*n\n" +
+	"\n" +
+	"ScanStatus\x12\x17\n" +
+	"\x13SCAN_STATUS_PENDING\x10\x00\x12\x15\n" +
+	"\x11SCAN_STATUS_CLEAN\x10\x01\x12\x18\n" +
+	"\x14SCAN_STATUS_INFECTED\x10\x02\x12\x16\n" +
+	"\x12SCAN_STATUS_FAILED\x10\x032\x9b!\n" +
+	"\vFileService\x12A\n" +
+	"\n" +
+	"UploadFile\x12\x17.file.UploadFileRequest\x1a\x18.file.UploadFileResponse(\x01\x12h\n" +
+	"\x0fUploadFileUnary\x12\x1c.file.UploadFileUnaryRequest\x1a\x1d.file.UploadFileUnaryResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/files/upload\x12J\n" +
+	"\rUploadArchive\x12\x1a.file.UploadArchiveRequest\x1a\x1b.file.UploadArchiveResponse(\x01\x12g\n" +
+	"\x13CreateUploadSession\x12 .file.CreateUploadSessionRequest\x1a\x13.file.UploadSession\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/files/uploads\x12L\n" +
+	"\x12WriteUploadSession\x12\x1f.file.WriteUploadSessionRequest\x1a\x13.file.UploadSession(\x01\x12k\n" +
+	"\x10GetUploadSession\x12\x1d.file.GetUploadSessionRequest\x1a\x13.file.UploadSession\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/files/uploads/{session_id}\x12\x83\x01\n" +
+	"\x15FinalizeUploadSession\x12\".file.FinalizeUploadSessionRequest\x1a\x18.file.UploadFileResponse\",\x82\xd3\xe4\x93\x02&\"$/files/uploads/{session_id}/finalize\x12|\n" +
+	"\x12AbortUploadSession\x12\x1f.file.AbortUploadSessionRequest\x1a .file.AbortUploadSessionResponse\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/files/uploads/{session_id}\x12l\n" +
+	"\x0fCreateUploadURL\x12\x1c.file.CreateUploadURLRequest\x1a\x1d.file.CreateUploadURLResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/files/upload-url\x12t\n" +
+	"\rConfirmUpload\x12\x1a.file.ConfirmUploadRequest\x1a\x18.file.UploadFileResponse\"-\x82\xd3\xe4\x93\x02'\"%/files/upload-url/{upload_id}/confirm\x12{\n" +
+	"\x11CreateDownloadURL\x12\x1e.file.CreateDownloadURLRequest\x1a\x1f.file.CreateDownloadURLResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/files/{file_id}/download-url\x12G\n" +
+	"\fDownloadFile\x12\x19.file.DownloadFileRequest\x1a\x1a.file.DownloadFileResponse0\x01\x12r\n" +
+	"\x11DownloadFileUnary\x12\x1e.file.DownloadFileUnaryRequest\x1a\x1f.file.DownloadFileUnaryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/files/get/{file_id}\x12P\n" +
+	"\x0fDownloadArchive\x12\x1c.file.DownloadArchiveRequest\x1a\x1d.file.DownloadArchiveResponse0\x01\x12m\n" +
+	"\x0eGetFilePreview\x12\x1b.file.GetFilePreviewRequest\x1a\x1c.file.GetFilePreviewResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/files/{file_id}/preview\x12t\n" +
+	"\x10ListFileVersions\x12\x1d.file.ListFileVersionsRequest\x1a\x1e.file.ListFileVersionsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/files/{file_id}/versions\x12U\n" +
+	"\x13DownloadFileVersion\x12 .file.DownloadFileVersionRequest\x1a\x1a.file.DownloadFileResponse0\x01\x12v\n" +
+	"\x12RestoreFileVersion\x12\x1f.file.RestoreFileVersionRequest\x1a\n" +
+	".file.File\"3\x82\xd3\xe4\x93\x02-\"+/files/{file_id}/versions/{version}/restore\x12E\n" +
 	"\aGetFile\x12\x14.file.GetFileRequest\x1a\n" +
 	".file.File\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/files/{file_id}\x12Y\n" +
 	"\n" +
-	"DeleteFile\x12\x17.file.DeleteFileRequest\x1a\x18.file.DeleteFileResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/files/{file_id}\x12g\n" +
+	"DeleteFile\x12\x17.file.DeleteFileRequest\x1a\x18.file.DeleteFileResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/files/{file_id}\x12^\n" +
+	"\x12UpdateFileMetadata\x12\x1f.file.UpdateFileMetadataRequest\x1a\n" +
+	".file.File\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/files/{file_id}\x12O\n" +
+	"\bMoveFile\x12\x15.file.MoveFileRequest\x1a\n" +
+	".file.File\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/files/{file_id}/move\x12O\n" +
+	"\bCopyFile\x12\x15.file.CopyFileRequest\x1a\n" +
+	".file.File\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/files/{file_id}/copy\x12U\n" +
+	"\vAddFileTags\x12\x18.file.AddFileTagsRequest\x1a\n" +
+	".file.File\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/files/{file_id}/tags\x12X\n" +
+	"\x0eRemoveFileTags\x12\x1b.file.RemoveFileTagsRequest\x1a\n" +
+	".file.File\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/files/{file_id}/tags\x12a\n" +
+	"\x0fSetFileMetadata\x12\x1c.file.SetFileMetadataRequest\x1a\n" +
+	".file.File\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\x1a\x19/files/{file_id}/metadata\x12g\n" +
 	"\x0fListFilesByUser\x12\x1c.file.ListFilesByUserRequest\x1a\x17.file.ListFilesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/files/user/{user_id}\x12o\n" +
 	"\x11ListFilesByCourse\x12\x1e.file.ListFilesByCourseRequest\x1a\x17.file.ListFilesResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/files/course/{course_id}\x12k\n" +
-	"\x10ListFilesByGroup\x12\x1d.file.ListFilesByGroupRequest\x1a\x17.file.ListFilesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/files/group/{group_id}B\n" +
+	"\x10ListFilesByGroup\x12\x1d.file.ListFilesByGroupRequest\x1a\x17.file.ListFilesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/files/group/{group_id}\x12Y\n" +
+	"\vSearchFiles\x12\x18.file.SearchFilesRequest\x1a\x19.file.SearchFilesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/files/search\x12R\n" +
+	"\tListTrash\x12\x16.file.ListTrashRequest\x1a\x17.file.ListFilesResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/files/trash\x12[\n" +
+	"\vRestoreFile\x12\x18.file.RestoreFileRequest\x1a\n" +
+	".file.File\"&\x82\xd3\xe4\x93\x02 \"\x1e/files/trash/{file_id}/restore\x12\\\n" +
+	"\tPurgeFile\x12\x16.file.PurgeFileRequest\x1a\x17.file.PurgeFileResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/files/trash/{file_id}\x12L\n" +
+	"\fCreateFolder\x12\x19.file.CreateFolderRequest\x1a\f.file.Folder\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/folders\x12X\n" +
+	"\fRenameFolder\x12\x19.file.RenameFolderRequest\x1a\f.file.Folder\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*2\x14/folders/{folder_id}\x12Y\n" +
+	"\n" +
+	"MoveFolder\x12\x17.file.MoveFolderRequest\x1a\f.file.Folder\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/folders/{folder_id}/move\x12c\n" +
+	"\fDeleteFolder\x12\x19.file.DeleteFolderRequest\x1a\x1a.file.DeleteFolderResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/folders/{folder_id}\x12Q\n" +
+	"\n" +
+	"ListFolder\x12\x17.file.ListFolderRequest\x1a\x18.file.ListFolderResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/folders\x12i\n" +
+	"\x0fCreateShareLink\x12\x1c.file.CreateShareLinkRequest\x1a\x0f.file.ShareLink\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/files/{file_id}/share-links\x12q\n" +
+	"\x0eListShareLinks\x12\x1b.file.ListShareLinksRequest\x1a\x1c.file.ListShareLinksResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/files/{file_id}/share-links\x12n\n" +
+	"\x0fRevokeShareLink\x12\x1c.file.RevokeShareLinkRequest\x1a\x1d.file.RevokeShareLinkResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/share-links/{link_id}\x127\n" +
+	"\rGetSharedFile\x12\x1a.file.GetSharedFileRequest\x1a\n" +
+	".file.File\x12K\n" +
+	"\x0eDownloadShared\x12\x1b.file.DownloadSharedRequest\x1a\x1a.file.DownloadFileResponse0\x01\x12O\n" +
+	"\bGetUsage\x12\x15.file.GetUsageRequest\x1a\x16.file.GetUsageResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/files/usageB\n" +
 	"Z\b/pubfileb\x06proto3"
 
 var (
@@ -842,45 +5851,247 @@ func file_file_public_fl_proto_rawDescGZIP() []byte {
 	return file_file_public_fl_proto_rawDescData
 }
 
-var file_file_public_fl_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_file_public_fl_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_file_public_fl_proto_msgTypes = make([]protoimpl.MessageInfo, 85)
 var file_file_public_fl_proto_goTypes = []any{
-	(*UploadFileUnaryRequest)(nil),    // 0: file.UploadFileUnaryRequest
-	(*UploadFileUnaryResponse)(nil),   // 1: file.UploadFileUnaryResponse
-	(*DownloadFileUnaryRequest)(nil),  // 2: file.DownloadFileUnaryRequest
-	(*DownloadFileUnaryResponse)(nil), // 3: file.DownloadFileUnaryResponse
-	(*GetFileRequest)(nil),            // 4: file.GetFileRequest
-	(*DeleteFileRequest)(nil),         // 5: file.DeleteFileRequest
-	(*DeleteFileResponse)(nil),        // 6: file.DeleteFileResponse
-	(*ListFilesByUserRequest)(nil),    // 7: file.ListFilesByUserRequest
-	(*ListFilesByCourseRequest)(nil),  // 8: file.ListFilesByCourseRequest
-	(*ListFilesByGroupRequest)(nil),   // 9: file.ListFilesByGroupRequest
-	(*ListFilesResponse)(nil),         // 10: file.ListFilesResponse
-	(*File)(nil),                      // 11: file.File
-	(*FileMetadata)(nil),              // 12: file.FileMetadata
-	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
+	(FileSortField)(0),                   // 0: file.FileSortField
+	(ScanStatus)(0),                      // 1: file.ScanStatus
+	(*UploadFileRequest)(nil),            // 2: file.UploadFileRequest
+	(*UploadFileResponse)(nil),           // 3: file.UploadFileResponse
+	(*UploadFileUnaryRequest)(nil),       // 4: file.UploadFileUnaryRequest
+	(*UploadFileUnaryResponse)(nil),      // 5: file.UploadFileUnaryResponse
+	(*UploadArchiveMetadata)(nil),        // 6: file.UploadArchiveMetadata
+	(*UploadArchiveRequest)(nil),         // 7: file.UploadArchiveRequest
+	(*ArchiveEntryResult)(nil),           // 8: file.ArchiveEntryResult
+	(*UploadArchiveResponse)(nil),        // 9: file.UploadArchiveResponse
+	(*CreateUploadSessionRequest)(nil),   // 10: file.CreateUploadSessionRequest
+	(*UploadSession)(nil),                // 11: file.UploadSession
+	(*UploadChunkHeader)(nil),            // 12: file.UploadChunkHeader
+	(*WriteUploadSessionRequest)(nil),    // 13: file.WriteUploadSessionRequest
+	(*GetUploadSessionRequest)(nil),      // 14: file.GetUploadSessionRequest
+	(*FinalizeUploadSessionRequest)(nil), // 15: file.FinalizeUploadSessionRequest
+	(*AbortUploadSessionRequest)(nil),    // 16: file.AbortUploadSessionRequest
+	(*AbortUploadSessionResponse)(nil),   // 17: file.AbortUploadSessionResponse
+	(*CreateUploadURLRequest)(nil),       // 18: file.CreateUploadURLRequest
+	(*CreateUploadURLResponse)(nil),      // 19: file.CreateUploadURLResponse
+	(*ConfirmUploadRequest)(nil),         // 20: file.ConfirmUploadRequest
+	(*CreateDownloadURLRequest)(nil),     // 21: file.CreateDownloadURLRequest
+	(*CreateDownloadURLResponse)(nil),    // 22: file.CreateDownloadURLResponse
+	(*DownloadFileRequest)(nil),          // 23: file.DownloadFileRequest
+	(*DownloadFileHeader)(nil),           // 24: file.DownloadFileHeader
+	(*DownloadFileResponse)(nil),         // 25: file.DownloadFileResponse
+	(*DownloadFileUnaryRequest)(nil),     // 26: file.DownloadFileUnaryRequest
+	(*DownloadFileUnaryResponse)(nil),    // 27: file.DownloadFileUnaryResponse
+	(*DownloadArchiveRequest)(nil),       // 28: file.DownloadArchiveRequest
+	(*DownloadArchiveHeader)(nil),        // 29: file.DownloadArchiveHeader
+	(*DownloadArchiveResponse)(nil),      // 30: file.DownloadArchiveResponse
+	(*GetFilePreviewRequest)(nil),        // 31: file.GetFilePreviewRequest
+	(*GetFilePreviewResponse)(nil),       // 32: file.GetFilePreviewResponse
+	(*ListFileVersionsRequest)(nil),      // 33: file.ListFileVersionsRequest
+	(*ListFileVersionsResponse)(nil),     // 34: file.ListFileVersionsResponse
+	(*DownloadFileVersionRequest)(nil),   // 35: file.DownloadFileVersionRequest
+	(*RestoreFileVersionRequest)(nil),    // 36: file.RestoreFileVersionRequest
+	(*GetFileRequest)(nil),               // 37: file.GetFileRequest
+	(*DeleteFileRequest)(nil),            // 38: file.DeleteFileRequest
+	(*DeleteFileResponse)(nil),           // 39: file.DeleteFileResponse
+	(*UpdateFileMetadataRequest)(nil),    // 40: file.UpdateFileMetadataRequest
+	(*MoveFileRequest)(nil),              // 41: file.MoveFileRequest
+	(*CopyFileRequest)(nil),              // 42: file.CopyFileRequest
+	(*AddFileTagsRequest)(nil),           // 43: file.AddFileTagsRequest
+	(*RemoveFileTagsRequest)(nil),        // 44: file.RemoveFileTagsRequest
+	(*SetFileMetadataRequest)(nil),       // 45: file.SetFileMetadataRequest
+	(*ListFilesByUserRequest)(nil),       // 46: file.ListFilesByUserRequest
+	(*ListFilesByCourseRequest)(nil),     // 47: file.ListFilesByCourseRequest
+	(*ListFilesByGroupRequest)(nil),      // 48: file.ListFilesByGroupRequest
+	(*ListFilesResponse)(nil),            // 49: file.ListFilesResponse
+	(*SearchFilesRequest)(nil),           // 50: file.SearchFilesRequest
+	(*SearchHit)(nil),                    // 51: file.SearchHit
+	(*SearchFilesResponse)(nil),          // 52: file.SearchFilesResponse
+	(*ListTrashRequest)(nil),             // 53: file.ListTrashRequest
+	(*RestoreFileRequest)(nil),           // 54: file.RestoreFileRequest
+	(*PurgeFileRequest)(nil),             // 55: file.PurgeFileRequest
+	(*PurgeFileResponse)(nil),            // 56: file.PurgeFileResponse
+	(*CreateFolderRequest)(nil),          // 57: file.CreateFolderRequest
+	(*RenameFolderRequest)(nil),          // 58: file.RenameFolderRequest
+	(*MoveFolderRequest)(nil),            // 59: file.MoveFolderRequest
+	(*DeleteFolderRequest)(nil),          // 60: file.DeleteFolderRequest
+	(*DeleteFolderResponse)(nil),         // 61: file.DeleteFolderResponse
+	(*ListFolderRequest)(nil),            // 62: file.ListFolderRequest
+	(*ListFolderResponse)(nil),           // 63: file.ListFolderResponse
+	(*CreateShareLinkRequest)(nil),       // 64: file.CreateShareLinkRequest
+	(*ListShareLinksRequest)(nil),        // 65: file.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),       // 66: file.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),       // 67: file.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),      // 68: file.RevokeShareLinkResponse
+	(*GetSharedFileRequest)(nil),         // 69: file.GetSharedFileRequest
+	(*DownloadSharedRequest)(nil),        // 70: file.DownloadSharedRequest
+	(*GetUsageRequest)(nil),              // 71: file.GetUsageRequest
+	(*Usage)(nil),                        // 72: file.Usage
+	(*GetUsageResponse)(nil),             // 73: file.GetUsageResponse
+	(*File)(nil),                         // 74: file.File
+	(*ShareLink)(nil),                    // 75: file.ShareLink
+	(*Folder)(nil),                       // 76: file.Folder
+	(*FileVersion)(nil),                  // 77: file.FileVersion
+	(*FileMetadata)(nil),                 // 78: file.FileMetadata
+	nil,                                  // 79: file.CreateUploadURLResponse.HeadersEntry
+	nil,                                  // 80: file.SetFileMetadataRequest.MetadataEntry
+	nil,                                  // 81: file.ListFilesByUserRequest.MetadataEntry
+	nil,                                  // 82: file.ListFilesByCourseRequest.MetadataEntry
+	nil,                                  // 83: file.ListFilesByGroupRequest.MetadataEntry
+	nil,                                  // 84: file.ListTrashRequest.MetadataEntry
+	nil,                                  // 85: file.ListFolderRequest.MetadataEntry
+	nil,                                  // 86: file.File.MetadataEntry
+	(*timestamppb.Timestamp)(nil),        // 87: google.protobuf.Timestamp
 }
 var file_file_public_fl_proto_depIdxs = []int32{
-	11, // 0: file.ListFilesResponse.files:type_name -> file.File
-	13, // 1: file.File.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: file.FileService.UploadFileUnary:input_type -> file.UploadFileUnaryRequest
-	2,  // 3: file.FileService.DownloadFileUnary:input_type -> file.DownloadFileUnaryRequest
-	4,  // 4: file.FileService.GetFile:input_type -> file.GetFileRequest
-	5,  // 5: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
-	7,  // 6: file.FileService.ListFilesByUser:input_type -> file.ListFilesByUserRequest
-	8,  // 7: file.FileService.ListFilesByCourse:input_type -> file.ListFilesByCourseRequest
-	9,  // 8: file.FileService.ListFilesByGroup:input_type -> file.ListFilesByGroupRequest
-	1,  // 9: file.FileService.UploadFileUnary:output_type -> file.UploadFileUnaryResponse
-	3,  // 10: file.FileService.DownloadFileUnary:output_type -> file.DownloadFileUnaryResponse
-	11, // 11: file.FileService.GetFile:output_type -> file.File
-	6,  // 12: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
-	10, // 13: file.FileService.ListFilesByUser:output_type -> file.ListFilesResponse
-	10, // 14: file.FileService.ListFilesByCourse:output_type -> file.ListFilesResponse
-	10, // 15: file.FileService.ListFilesByGroup:output_type -> file.ListFilesResponse
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	78, // 0: file.UploadFileRequest.metadata:type_name -> file.FileMetadata
+	6,  // 1: file.UploadArchiveRequest.metadata:type_name -> file.UploadArchiveMetadata
+	8,  // 2: file.UploadArchiveResponse.entries:type_name -> file.ArchiveEntryResult
+	78, // 3: file.CreateUploadSessionRequest.metadata:type_name -> file.FileMetadata
+	87, // 4: file.UploadSession.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 5: file.WriteUploadSessionRequest.header:type_name -> file.UploadChunkHeader
+	78, // 6: file.CreateUploadURLRequest.metadata:type_name -> file.FileMetadata
+	79, // 7: file.CreateUploadURLResponse.headers:type_name -> file.CreateUploadURLResponse.HeadersEntry
+	87, // 8: file.CreateUploadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	87, // 9: file.CreateDownloadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	74, // 10: file.DownloadFileHeader.file:type_name -> file.File
+	24, // 11: file.DownloadFileResponse.header:type_name -> file.DownloadFileHeader
+	29, // 12: file.DownloadArchiveResponse.header:type_name -> file.DownloadArchiveHeader
+	87, // 13: file.GetFilePreviewResponse.expires_at:type_name -> google.protobuf.Timestamp
+	77, // 14: file.ListFileVersionsResponse.versions:type_name -> file.FileVersion
+	80, // 15: file.SetFileMetadataRequest.metadata:type_name -> file.SetFileMetadataRequest.MetadataEntry
+	0,  // 16: file.ListFilesByUserRequest.sort_by:type_name -> file.FileSortField
+	87, // 17: file.ListFilesByUserRequest.created_from:type_name -> google.protobuf.Timestamp
+	87, // 18: file.ListFilesByUserRequest.created_to:type_name -> google.protobuf.Timestamp
+	81, // 19: file.ListFilesByUserRequest.metadata:type_name -> file.ListFilesByUserRequest.MetadataEntry
+	0,  // 20: file.ListFilesByCourseRequest.sort_by:type_name -> file.FileSortField
+	87, // 21: file.ListFilesByCourseRequest.created_from:type_name -> google.protobuf.Timestamp
+	87, // 22: file.ListFilesByCourseRequest.created_to:type_name -> google.protobuf.Timestamp
+	82, // 23: file.ListFilesByCourseRequest.metadata:type_name -> file.ListFilesByCourseRequest.MetadataEntry
+	0,  // 24: file.ListFilesByGroupRequest.sort_by:type_name -> file.FileSortField
+	87, // 25: file.ListFilesByGroupRequest.created_from:type_name -> google.protobuf.Timestamp
+	87, // 26: file.ListFilesByGroupRequest.created_to:type_name -> google.protobuf.Timestamp
+	83, // 27: file.ListFilesByGroupRequest.metadata:type_name -> file.ListFilesByGroupRequest.MetadataEntry
+	74, // 28: file.ListFilesResponse.files:type_name -> file.File
+	74, // 29: file.SearchHit.file:type_name -> file.File
+	51, // 30: file.SearchFilesResponse.hits:type_name -> file.SearchHit
+	0,  // 31: file.ListTrashRequest.sort_by:type_name -> file.FileSortField
+	87, // 32: file.ListTrashRequest.created_from:type_name -> google.protobuf.Timestamp
+	87, // 33: file.ListTrashRequest.created_to:type_name -> google.protobuf.Timestamp
+	84, // 34: file.ListTrashRequest.metadata:type_name -> file.ListTrashRequest.MetadataEntry
+	0,  // 35: file.ListFolderRequest.sort_by:type_name -> file.FileSortField
+	87, // 36: file.ListFolderRequest.created_from:type_name -> google.protobuf.Timestamp
+	87, // 37: file.ListFolderRequest.created_to:type_name -> google.protobuf.Timestamp
+	85, // 38: file.ListFolderRequest.metadata:type_name -> file.ListFolderRequest.MetadataEntry
+	76, // 39: file.ListFolderResponse.folder:type_name -> file.Folder
+	76, // 40: file.ListFolderResponse.folders:type_name -> file.Folder
+	74, // 41: file.ListFolderResponse.files:type_name -> file.File
+	87, // 42: file.CreateShareLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	75, // 43: file.ListShareLinksResponse.links:type_name -> file.ShareLink
+	72, // 44: file.GetUsageResponse.user:type_name -> file.Usage
+	72, // 45: file.GetUsageResponse.course:type_name -> file.Usage
+	87, // 46: file.File.created_at:type_name -> google.protobuf.Timestamp
+	87, // 47: file.File.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 48: file.File.scan_status:type_name -> file.ScanStatus
+	86, // 49: file.File.metadata:type_name -> file.File.MetadataEntry
+	87, // 50: file.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	87, // 51: file.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	87, // 52: file.Folder.created_at:type_name -> google.protobuf.Timestamp
+	87, // 53: file.FileVersion.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 54: file.FileVersion.scan_status:type_name -> file.ScanStatus
+	2,  // 55: file.FileService.UploadFile:input_type -> file.UploadFileRequest
+	4,  // 56: file.FileService.UploadFileUnary:input_type -> file.UploadFileUnaryRequest
+	7,  // 57: file.FileService.UploadArchive:input_type -> file.UploadArchiveRequest
+	10, // 58: file.FileService.CreateUploadSession:input_type -> file.CreateUploadSessionRequest
+	13, // 59: file.FileService.WriteUploadSession:input_type -> file.WriteUploadSessionRequest
+	14, // 60: file.FileService.GetUploadSession:input_type -> file.GetUploadSessionRequest
+	15, // 61: file.FileService.FinalizeUploadSession:input_type -> file.FinalizeUploadSessionRequest
+	16, // 62: file.FileService.AbortUploadSession:input_type -> file.AbortUploadSessionRequest
+	18, // 63: file.FileService.CreateUploadURL:input_type -> file.CreateUploadURLRequest
+	20, // 64: file.FileService.ConfirmUpload:input_type -> file.ConfirmUploadRequest
+	21, // 65: file.FileService.CreateDownloadURL:input_type -> file.CreateDownloadURLRequest
+	23, // 66: file.FileService.DownloadFile:input_type -> file.DownloadFileRequest
+	26, // 67: file.FileService.DownloadFileUnary:input_type -> file.DownloadFileUnaryRequest
+	28, // 68: file.FileService.DownloadArchive:input_type -> file.DownloadArchiveRequest
+	31, // 69: file.FileService.GetFilePreview:input_type -> file.GetFilePreviewRequest
+	33, // 70: file.FileService.ListFileVersions:input_type -> file.ListFileVersionsRequest
+	35, // 71: file.FileService.DownloadFileVersion:input_type -> file.DownloadFileVersionRequest
+	36, // 72: file.FileService.RestoreFileVersion:input_type -> file.RestoreFileVersionRequest
+	37, // 73: file.FileService.GetFile:input_type -> file.GetFileRequest
+	38, // 74: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
+	40, // 75: file.FileService.UpdateFileMetadata:input_type -> file.UpdateFileMetadataRequest
+	41, // 76: file.FileService.MoveFile:input_type -> file.MoveFileRequest
+	42, // 77: file.FileService.CopyFile:input_type -> file.CopyFileRequest
+	43, // 78: file.FileService.AddFileTags:input_type -> file.AddFileTagsRequest
+	44, // 79: file.FileService.RemoveFileTags:input_type -> file.RemoveFileTagsRequest
+	45, // 80: file.FileService.SetFileMetadata:input_type -> file.SetFileMetadataRequest
+	46, // 81: file.FileService.ListFilesByUser:input_type -> file.ListFilesByUserRequest
+	47, // 82: file.FileService.ListFilesByCourse:input_type -> file.ListFilesByCourseRequest
+	48, // 83: file.FileService.ListFilesByGroup:input_type -> file.ListFilesByGroupRequest
+	50, // 84: file.FileService.SearchFiles:input_type -> file.SearchFilesRequest
+	53, // 85: file.FileService.ListTrash:input_type -> file.ListTrashRequest
+	54, // 86: file.FileService.RestoreFile:input_type -> file.RestoreFileRequest
+	55, // 87: file.FileService.PurgeFile:input_type -> file.PurgeFileRequest
+	57, // 88: file.FileService.CreateFolder:input_type -> file.CreateFolderRequest
+	58, // 89: file.FileService.RenameFolder:input_type -> file.RenameFolderRequest
+	59, // 90: file.FileService.MoveFolder:input_type -> file.MoveFolderRequest
+	60, // 91: file.FileService.DeleteFolder:input_type -> file.DeleteFolderRequest
+	62, // 92: file.FileService.ListFolder:input_type -> file.ListFolderRequest
+	64, // 93: file.FileService.CreateShareLink:input_type -> file.CreateShareLinkRequest
+	65, // 94: file.FileService.ListShareLinks:input_type -> file.ListShareLinksRequest
+	67, // 95: file.FileService.RevokeShareLink:input_type -> file.RevokeShareLinkRequest
+	69, // 96: file.FileService.GetSharedFile:input_type -> file.GetSharedFileRequest
+	70, // 97: file.FileService.DownloadShared:input_type -> file.DownloadSharedRequest
+	71, // 98: file.FileService.GetUsage:input_type -> file.GetUsageRequest
+	3,  // 99: file.FileService.UploadFile:output_type -> file.UploadFileResponse
+	5,  // 100: file.FileService.UploadFileUnary:output_type -> file.UploadFileUnaryResponse
+	9,  // 101: file.FileService.UploadArchive:output_type -> file.UploadArchiveResponse
+	11, // 102: file.FileService.CreateUploadSession:output_type -> file.UploadSession
+	11, // 103: file.FileService.WriteUploadSession:output_type -> file.UploadSession
+	11, // 104: file.FileService.GetUploadSession:output_type -> file.UploadSession
+	3,  // 105: file.FileService.FinalizeUploadSession:output_type -> file.UploadFileResponse
+	17, // 106: file.FileService.AbortUploadSession:output_type -> file.AbortUploadSessionResponse
+	19, // 107: file.FileService.CreateUploadURL:output_type -> file.CreateUploadURLResponse
+	3,  // 108: file.FileService.ConfirmUpload:output_type -> file.UploadFileResponse
+	22, // 109: file.FileService.CreateDownloadURL:output_type -> file.CreateDownloadURLResponse
+	25, // 110: file.FileService.DownloadFile:output_type -> file.DownloadFileResponse
+	27, // 111: file.FileService.DownloadFileUnary:output_type -> file.DownloadFileUnaryResponse
+	30, // 112: file.FileService.DownloadArchive:output_type -> file.DownloadArchiveResponse
+	32, // 113: file.FileService.GetFilePreview:output_type -> file.GetFilePreviewResponse
+	34, // 114: file.FileService.ListFileVersions:output_type -> file.ListFileVersionsResponse
+	25, // 115: file.FileService.DownloadFileVersion:output_type -> file.DownloadFileResponse
+	74, // 116: file.FileService.RestoreFileVersion:output_type -> file.File
+	74, // 117: file.FileService.GetFile:output_type -> file.File
+	39, // 118: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
+	74, // 119: file.FileService.UpdateFileMetadata:output_type -> file.File
+	74, // 120: file.FileService.MoveFile:output_type -> file.File
+	74, // 121: file.FileService.CopyFile:output_type -> file.File
+	74, // 122: file.FileService.AddFileTags:output_type -> file.File
+	74, // 123: file.FileService.RemoveFileTags:output_type -> file.File
+	74, // 124: file.FileService.SetFileMetadata:output_type -> file.File
+	49, // 125: file.FileService.ListFilesByUser:output_type -> file.ListFilesResponse
+	49, // 126: file.FileService.ListFilesByCourse:output_type -> file.ListFilesResponse
+	49, // 127: file.FileService.ListFilesByGroup:output_type -> file.ListFilesResponse
+	52, // 128: file.FileService.SearchFiles:output_type -> file.SearchFilesResponse
+	49, // 129: file.FileService.ListTrash:output_type -> file.ListFilesResponse
+	74, // 130: file.FileService.RestoreFile:output_type -> file.File
+	56, // 131: file.FileService.PurgeFile:output_type -> file.PurgeFileResponse
+	76, // 132: file.FileService.CreateFolder:output_type -> file.Folder
+	76, // 133: file.FileService.RenameFolder:output_type -> file.Folder
+	76, // 134: file.FileService.MoveFolder:output_type -> file.Folder
+	61, // 135: file.FileService.DeleteFolder:output_type -> file.DeleteFolderResponse
+	63, // 136: file.FileService.ListFolder:output_type -> file.ListFolderResponse
+	75, // 137: file.FileService.CreateShareLink:output_type -> file.ShareLink
+	66, // 138: file.FileService.ListShareLinks:output_type -> file.ListShareLinksResponse
+	68, // 139: file.FileService.RevokeShareLink:output_type -> file.RevokeShareLinkResponse
+	74, // 140: file.FileService.GetSharedFile:output_type -> file.File
+	25, // 141: file.FileService.DownloadShared:output_type -> file.DownloadFileResponse
+	73, // 142: file.FileService.GetUsage:output_type -> file.GetUsageResponse
+	99, // [99:143] is the sub-list for method output_type
+	55, // [55:99] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
 }
 
 func init() { file_file_public_fl_proto_init() }
@@ -888,18 +6099,39 @@ func file_file_public_fl_proto_init() {
 	if File_file_public_fl_proto != nil {
 		return
 	}
+	file_file_public_fl_proto_msgTypes[0].OneofWrappers = []any{
+		(*UploadFileRequest_Metadata)(nil),
+		(*UploadFileRequest_Chunk)(nil),
+	}
+	file_file_public_fl_proto_msgTypes[5].OneofWrappers = []any{
+		(*UploadArchiveRequest_Metadata)(nil),
+		(*UploadArchiveRequest_Chunk)(nil),
+	}
+	file_file_public_fl_proto_msgTypes[11].OneofWrappers = []any{
+		(*WriteUploadSessionRequest_Header)(nil),
+		(*WriteUploadSessionRequest_Chunk)(nil),
+	}
+	file_file_public_fl_proto_msgTypes[23].OneofWrappers = []any{
+		(*DownloadFileResponse_Header)(nil),
+		(*DownloadFileResponse_Chunk)(nil),
+	}
+	file_file_public_fl_proto_msgTypes[28].OneofWrappers = []any{
+		(*DownloadArchiveResponse_Header)(nil),
+		(*DownloadArchiveResponse_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_file_public_fl_proto_rawDesc), len(file_file_public_fl_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   85,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_file_public_fl_proto_goTypes,
 		DependencyIndexes: file_file_public_fl_proto_depIdxs,
+		EnumInfos:         file_file_public_fl_proto_enumTypes,
 		MessageInfos:      file_file_public_fl_proto_msgTypes,
 	}.Build()
 	File_file_public_fl_proto = out.File
@@ -850,6 +850,118 @@ func (x *UserDataRequest) GetEmail() string {
 	return ""
 }
 
+type GetUserAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserAccessRequest) Reset() {
+	*x = GetUserAccessRequest{}
+	mi := &file_proto_user_internal_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserAccessRequest) ProtoMessage() {}
+
+func (x *GetUserAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_internal_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserAccessRequest.ProtoReflect.Descriptor instead.
+func (*GetUserAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_internal_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetUserAccessRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type GetUserAccessResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Role             string                 `protobuf:"bytes,1,opt,name=Role,proto3" json:"Role,omitempty"`
+	TeacherCourseIDs []string               `protobuf:"bytes,2,rep,name=TeacherCourseIDs,proto3" json:"TeacherCourseIDs,omitempty"`
+	CourseIDs        []string               `protobuf:"bytes,3,rep,name=CourseIDs,proto3" json:"CourseIDs,omitempty"`
+	GroupIDs         []string               `protobuf:"bytes,4,rep,name=GroupIDs,proto3" json:"GroupIDs,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetUserAccessResponse) Reset() {
+	*x = GetUserAccessResponse{}
+	mi := &file_proto_user_internal_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserAccessResponse) ProtoMessage() {}
+
+func (x *GetUserAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_internal_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserAccessResponse.ProtoReflect.Descriptor instead.
+func (*GetUserAccessResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_internal_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUserAccessResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetUserAccessResponse) GetTeacherCourseIDs() []string {
+	if x != nil {
+		return x.TeacherCourseIDs
+	}
+	return nil
+}
+
+func (x *GetUserAccessResponse) GetCourseIDs() []string {
+	if x != nil {
+		return x.CourseIDs
+	}
+	return nil
+}
+
+func (x *GetUserAccessResponse) GetGroupIDs() []string {
+	if x != nil {
+		return x.GroupIDs
+	}
+	return nil
+}
+
 var File_proto_user_internal_user_proto protoreflect.FileDescriptor
 
 const file_proto_user_internal_user_proto_rawDesc = "" +
@@ -905,7 +1017,14 @@ const file_proto_user_internal_user_proto_rawDesc = "" +
 	"\x13CoursesDataResponse\x126\n" +
 	"\vCoursesData\x18\x01 \x03(\v2\x14.user_int.CourseDataR\vCoursesData\"'\n" +
 	"\x0fUserDataRequest\x12\x14\n" +
-	"\x05Email\x18\x01 \x01(\tR\x05Email2\xb3\b\n" +
+	"\x05Email\x18\x01 \x01(\tR\x05Email\".\n" +
+	"\x14GetUserAccessRequest\x12\x16\n" +
+	"\x06UserID\x18\x01 \x01(\tR\x06UserID\"\x91\x01\n" +
+	"\x15GetUserAccessResponse\x12\x12\n" +
+	"\x04Role\x18\x01 \x01(\tR\x04Role\x12*\n" +
+	"\x10TeacherCourseIDs\x18\x02 \x03(\tR\x10TeacherCourseIDs\x12\x1c\n" +
+	"\tCourseIDs\x18\x03 \x03(\tR\tCourseIDs\x12\x1a\n" +
+	"\bGroupIDs\x18\x04 \x03(\tR\bGroupIDs2\x85\t\n" +
 	"\x04User\x12A\n" +
 	"\n" +
 	"CreateUser\x12\x1b.user_int.CreateUserRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
@@ -922,7 +1041,8 @@ const file_proto_user_internal_user_proto_rawDesc = "" +
 	"\x15AttachTeacherToCourse\x12$.user_int.AttachTeacherCourseRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
 	"\x15DetachTeacherToCourse\x12$.user_int.DetachTeacherCourseRequest\x1a\x16.google.protobuf.Empty\x12E\n" +
 	"\rGetGroupsData\x12\x16.google.protobuf.Empty\x1a\x1c.user_int.GroupsDataResponse\x12G\n" +
-	"\x0eGetCoursesData\x12\x16.google.protobuf.Empty\x1a\x1d.user_int.CoursesDataResponseB\n" +
+	"\x0eGetCoursesData\x12\x16.google.protobuf.Empty\x1a\x1d.user_int.CoursesDataResponse\x12P\n" +
+	"\rGetUserAccess\x12\x1e.user_int.GetUserAccessRequest\x1a\x1f.user_int.GetUserAccessResponseB\n" +
 	"Z\b/intuserb\x06proto3"
 
 var (
@@ -937,7 +1057,7 @@ func file_proto_user_internal_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_internal_user_proto_rawDescData
 }
 
-var file_proto_user_internal_user_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_user_internal_user_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_user_internal_user_proto_goTypes = []any{
 	(*AttachStudentToCourseRequest)(nil), // 0: user_int.AttachStudentToCourseRequest
 	(*DetachStudentToCourseRequest)(nil), // 1: user_int.DetachStudentToCourseRequest
@@ -956,7 +1076,9 @@ var file_proto_user_internal_user_proto_goTypes = []any{
 	(*CourseData)(nil),                   // 14: user_int.CourseData
 	(*CoursesDataResponse)(nil),          // 15: user_int.CoursesDataResponse
 	(*UserDataRequest)(nil),              // 16: user_int.UserDataRequest
-	(*emptypb.Empty)(nil),                // 17: google.protobuf.Empty
+	(*GetUserAccessRequest)(nil),         // 17: user_int.GetUserAccessRequest
+	(*GetUserAccessResponse)(nil),        // 18: user_int.GetUserAccessResponse
+	(*emptypb.Empty)(nil),                // 19: google.protobuf.Empty
 }
 var file_proto_user_internal_user_proto_depIdxs = []int32{
 	12, // 0: user_int.GroupsDataResponse.GroupsData:type_name -> user_int.GroupData
@@ -973,24 +1095,26 @@ var file_proto_user_internal_user_proto_depIdxs = []int32{
 	9,  // 11: user_int.User.DetachGroupFromCourse:input_type -> user_int.DetachGroupCourseRequest
 	10, // 12: user_int.User.AttachTeacherToCourse:input_type -> user_int.AttachTeacherCourseRequest
 	11, // 13: user_int.User.DetachTeacherToCourse:input_type -> user_int.DetachTeacherCourseRequest
-	17, // 14: user_int.User.GetGroupsData:input_type -> google.protobuf.Empty
-	17, // 15: user_int.User.GetCoursesData:input_type -> google.protobuf.Empty
-	17, // 16: user_int.User.CreateUser:output_type -> google.protobuf.Empty
-	17, // 17: user_int.User.DeleteUser:output_type -> google.protobuf.Empty
-	5,  // 18: user_int.User.CreateGroup:output_type -> user_int.CreateGroupResponse
-	17, // 19: user_int.User.DeleteGroup:output_type -> google.protobuf.Empty
-	7,  // 20: user_int.User.CreateCourse:output_type -> user_int.CreateCourseResponse
-	17, // 21: user_int.User.DeleteCourse:output_type -> google.protobuf.Empty
-	17, // 22: user_int.User.AttachStudentToCourse:output_type -> google.protobuf.Empty
-	17, // 23: user_int.User.DetachStudentToCourse:output_type -> google.protobuf.Empty
-	17, // 24: user_int.User.AttachGroupToCourse:output_type -> google.protobuf.Empty
-	17, // 25: user_int.User.DetachGroupFromCourse:output_type -> google.protobuf.Empty
-	17, // 26: user_int.User.AttachTeacherToCourse:output_type -> google.protobuf.Empty
-	17, // 27: user_int.User.DetachTeacherToCourse:output_type -> google.protobuf.Empty
-	13, // 28: user_int.User.GetGroupsData:output_type -> user_int.GroupsDataResponse
-	15, // 29: user_int.User.GetCoursesData:output_type -> user_int.CoursesDataResponse
-	16, // [16:30] is the sub-list for method output_type
-	2,  // [2:16] is the sub-list for method input_type
+	19, // 14: user_int.User.GetGroupsData:input_type -> google.protobuf.Empty
+	19, // 15: user_int.User.GetCoursesData:input_type -> google.protobuf.Empty
+	17, // 16: user_int.User.GetUserAccess:input_type -> user_int.GetUserAccessRequest
+	19, // 17: user_int.User.CreateUser:output_type -> google.protobuf.Empty
+	19, // 18: user_int.User.DeleteUser:output_type -> google.protobuf.Empty
+	5,  // 19: user_int.User.CreateGroup:output_type -> user_int.CreateGroupResponse
+	19, // 20: user_int.User.DeleteGroup:output_type -> google.protobuf.Empty
+	7,  // 21: user_int.User.CreateCourse:output_type -> user_int.CreateCourseResponse
+	19, // 22: user_int.User.DeleteCourse:output_type -> google.protobuf.Empty
+	19, // 23: user_int.User.AttachStudentToCourse:output_type -> google.protobuf.Empty
+	19, // 24: user_int.User.DetachStudentToCourse:output_type -> google.protobuf.Empty
+	19, // 25: user_int.User.AttachGroupToCourse:output_type -> google.protobuf.Empty
+	19, // 26: user_int.User.DetachGroupFromCourse:output_type -> google.protobuf.Empty
+	19, // 27: user_int.User.AttachTeacherToCourse:output_type -> google.protobuf.Empty
+	19, // 28: user_int.User.DetachTeacherToCourse:output_type -> google.protobuf.Empty
+	13, // 29: user_int.User.GetGroupsData:output_type -> user_int.GroupsDataResponse
+	15, // 30: user_int.User.GetCoursesData:output_type -> user_int.CoursesDataResponse
+	18, // 31: user_int.User.GetUserAccess:output_type -> user_int.GetUserAccessResponse
+	17, // [17:32] is the sub-list for method output_type
+	2,  // [2:17] is the sub-list for method input_type
 	2,  // [2:2] is the sub-list for extension type_name
 	2,  // [2:2] is the sub-list for extension extendee
 	0,  // [0:2] is the sub-list for field type_name
@@ -1007,7 +1131,7 @@ func file_proto_user_internal_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_internal_user_proto_rawDesc), len(file_proto_user_internal_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
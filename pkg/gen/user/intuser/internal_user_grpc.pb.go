@@ -34,6 +34,7 @@ const (
 	User_DetachTeacherToCourse_FullMethodName = "/user_int.User/DetachTeacherToCourse"
 	User_GetGroupsData_FullMethodName         = "/user_int.User/GetGroupsData"
 	User_GetCoursesData_FullMethodName        = "/user_int.User/GetCoursesData"
+	User_GetUserAccess_FullMethodName         = "/user_int.User/GetUserAccess"
 )
 
 // UserClient is the client API for User service.
@@ -54,6 +55,7 @@ type UserClient interface {
 	DetachTeacherToCourse(ctx context.Context, in *DetachTeacherCourseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetGroupsData(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GroupsDataResponse, error)
 	GetCoursesData(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CoursesDataResponse, error)
+	GetUserAccess(ctx context.Context, in *GetUserAccessRequest, opts ...grpc.CallOption) (*GetUserAccessResponse, error)
 }
 
 type userClient struct {
@@ -204,6 +206,16 @@ func (c *userClient) GetCoursesData(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *userClient) GetUserAccess(ctx context.Context, in *GetUserAccessRequest, opts ...grpc.CallOption) (*GetUserAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserAccessResponse)
+	err := c.cc.Invoke(ctx, User_GetUserAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServer is the server API for User service.
 // All implementations must embed UnimplementedUserServer
 // for forward compatibility.
@@ -222,6 +234,7 @@ type UserServer interface {
 	DetachTeacherToCourse(context.Context, *DetachTeacherCourseRequest) (*emptypb.Empty, error)
 	GetGroupsData(context.Context, *emptypb.Empty) (*GroupsDataResponse, error)
 	GetCoursesData(context.Context, *emptypb.Empty) (*CoursesDataResponse, error)
+	GetUserAccess(context.Context, *GetUserAccessRequest) (*GetUserAccessResponse, error)
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -274,6 +287,9 @@ func (UnimplementedUserServer) GetGroupsData(context.Context, *emptypb.Empty) (*
 func (UnimplementedUserServer) GetCoursesData(context.Context, *emptypb.Empty) (*CoursesDataResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCoursesData not implemented")
 }
+func (UnimplementedUserServer) GetUserAccess(context.Context, *GetUserAccessRequest) (*GetUserAccessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserAccess not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 func (UnimplementedUserServer) testEmbeddedByValue()              {}
 
@@ -547,6 +563,24 @@ func _User_GetCoursesData_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_GetUserAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetUserAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_GetUserAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetUserAccess(ctx, req.(*GetUserAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // User_ServiceDesc is the grpc.ServiceDesc for User service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -610,6 +644,10 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCoursesData",
 			Handler:    _User_GetCoursesData_Handler,
 		},
+		{
+			MethodName: "GetUserAccess",
+			Handler:    _User_GetUserAccess_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user/internal_user.proto",
@@ -3,12 +3,29 @@ package main
 import (
 	"context"
 	"flag"
+	"os"
 
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/app"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/config"
 )
 
+var commands = map[string]func(){
+	"reconcile":  reconcile,
+	"rotatekeys": rotatekeys,
+	"storecheck": storecheck,
+}
+
 func main() {
+	// Подкоманды выполняют разовую задачу вместо запуска сервиса
+	if len(os.Args) > 1 {
+		command, ok := commands[os.Args[1]]
+		if ok {
+			os.Args = append(os.Args[:1], os.Args[2:]...)
+			command()
+			return
+		}
+	}
+
 	flag.Parse()
 
 	cfg := config.MustLoad()
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/config"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/reconciler"
+)
+
+// reconcile однократно сверяет хранилище с базой и печатает отчет:
+// fl reconcile [-repair] [-config path]
+func reconcile() {
+	repair := flag.Bool("repair", false, "delete orphaned objects and mark rows with missing content")
+
+	cfg := config.MustLoad()
+
+	log := zap.NewLogger(cfg.Logger).WithFields("layer", "reconcile")
+
+	st, err := file.New(log, cfg.Store)
+	if err != nil {
+		log.Fatalf("Failed to create store instance: %s", err)
+	}
+	defer st.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	report, err := reconciler.New(log, st, cfg.Reconciler).Reconcile(ctx, *repair)
+
+	fmt.Printf("objects checked:  %d\n", report.Objects)
+	fmt.Printf("keys checked:     %d\n", report.Keys)
+	fmt.Printf("orphaned objects: %d (%d bytes)\n", report.Orphans, report.OrphanSize)
+	for _, key := range report.OrphanKeys {
+		fmt.Printf("  %s\n", key)
+	}
+	fmt.Printf("missing objects:  %d\n", report.Missing)
+	for _, key := range report.MissingKeys {
+		fmt.Printf("  %s\n", key)
+	}
+	if *repair {
+		fmt.Printf("deleted objects:  %d\n", report.Deleted)
+		fmt.Printf("marked rows:      %d\n", report.Marked)
+	}
+
+	if err != nil {
+		log.Fatalf("Reconciliation failed: %s", err)
+	}
+}
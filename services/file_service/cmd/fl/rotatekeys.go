@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/config"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file"
+)
+
+// rotatekeys переоборачивает ключи курсов текущим мастер-ключом,
+// содержимое не перешифровывается: fl rotatekeys [-batch n] [-config path]
+func rotatekeys() {
+	batch := flag.Int("batch", 100, "data keys rewrapped per query")
+
+	cfg := config.MustLoad()
+
+	log := zap.NewLogger(cfg.Logger).WithFields("layer", "rotatekeys")
+
+	st, err := file.New(log, cfg.Store)
+	if err != nil {
+		log.Fatalf("Failed to create store instance: %s", err)
+	}
+	defer st.Close()
+
+	keyring, err := envelope.New(cfg.Store.Encryption, st.Keys())
+	if err != nil {
+		log.Fatalf("Failed to load master keys: %s", err)
+	}
+	if keyring == nil {
+		log.Fatalf("No master keys are configured")
+	}
+
+	rotated, err := keyring.Rotate(*batch)
+	fmt.Printf("rewrapped data keys: %d\n", rotated)
+
+	if err != nil {
+		log.Fatalf("Key rotation failed: %s", err)
+	}
+}
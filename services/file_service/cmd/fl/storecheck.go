@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/config"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/storetest"
+)
+
+// storecheck проверяет настроенное хранилище содержимого набором
+// storetest, база метаданных не нужна: fl storecheck [-config path]
+func storecheck() {
+	cfg := config.MustLoad()
+
+	log := zap.NewLogger(cfg.Logger).WithFields("layer", "storecheck")
+
+	content, err := file.NewContent(log, cfg.Store)
+	if err != nil {
+		log.Fatalf("Failed to open %s storage: %s", cfg.Store.Driver, err)
+	}
+
+	if err := storetest.Run(content.File, content.Multipart); err != nil {
+		fmt.Printf("%s storage failed checks:\n%s\n", cfg.Store.Driver, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s storage passed all checks\n", cfg.Store.Driver)
+}
@@ -11,12 +11,25 @@ import (
 	"syscall"
 
 	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/pkg/gen/user/intuser"
 	"github.com/alexey-dobry/fileshare/pkg/logger"
 	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/config"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/server/gateway"
 	rpc "github.com/alexey-dobry/fileshare/services/file_service/internal/server/grpc"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/indexer"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/previewer"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/purger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/reconciler"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/relay"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/scanner"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/scrubber"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/sessiongc"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -33,6 +46,10 @@ type app struct {
 	gatewayServer  *http.Server
 	gatewayAddress string
 
+	workers []worker.Worker
+
+	userConn *grpc.ClientConn
+
 	store  store.Store
 	logger logger.Logger
 }
@@ -55,7 +72,28 @@ func New(cfg config.Config) App {
 
 	a.logger.Info("Init store")
 
-	a.publicServer = rpc.NewPublicServer(a.logger, a.store)
+	a.userConn, err = grpc.NewClient(
+		cfg.Access.UserServiceAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		a.logger.Fatalf("Failed to create user service client: %s", err)
+	}
+
+	checker := access.New(a.logger, intuser.NewUserClient(a.userConn), cfg.Access)
+
+	a.publicServer = rpc.NewPublicServer(a.logger, a.store, cfg.GRPC, checker, policy.New(cfg.Policy))
+
+	a.workers = []worker.Worker{
+		sessiongc.New(a.logger, a.store, cfg.SessionGC),
+		purger.New(a.logger, a.store, cfg.Purger),
+		scanner.New(a.logger, a.store, cfg.Scanner),
+		previewer.New(a.logger, a.store, cfg.Previewer),
+		indexer.New(a.logger, a.store, cfg.Indexer),
+		relay.New(a.logger, a.store, cfg.Relay),
+		reconciler.New(a.logger, a.store, cfg.Reconciler),
+		scrubber.New(a.logger, a.store, cfg.Scrubber),
+	}
 
 	a.logger.Info("app was built")
 	return &a
@@ -102,6 +140,15 @@ func (a *app) Run(ctx context.Context) {
 		}
 	}()
 
+	// Background workers
+	for _, w := range a.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Run(ctx)
+		}()
+	}
+
 	a.logger.Info("App is running...")
 
 	select {
@@ -119,6 +166,8 @@ func (a *app) Run(ctx context.Context) {
 
 	wg.Wait()
 
+	_ = a.userConn.Close()
+
 	if err := a.store.Close(); err != nil {
 		a.logger.Warnf("store closing ended with error: %s", err)
 	}
@@ -138,14 +187,20 @@ func (a *app) initGateway(ctx context.Context) error {
 		return err
 	}
 
+	client := pb.NewFileServiceClient(conn)
+
 	if err := pb.RegisterFileServiceHandlerClient(
 		ctx,
 		mux,
-		pb.NewFileServiceClient(conn),
+		client,
 	); err != nil {
 		return err
 	}
 
+	if err := gateway.New(a.logger, mux, client).Register(); err != nil {
+		return err
+	}
+
 	a.gatewayServer = &http.Server{
 		Addr:    a.gatewayAddress,
 		Handler: mux,
@@ -7,8 +7,18 @@ import (
 
 	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
 	"github.com/alexey-dobry/fileshare/pkg/validator"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/server/grpc"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/indexer"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/previewer"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/purger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/reconciler"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/relay"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/scanner"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/scrubber"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker/sessiongc"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -16,6 +26,19 @@ type Config struct {
 	Logger zap.Config  `yaml:"logger"`
 	GRPC   grpc.Config `yaml:"grpc"`
 	Store  file.Config `yaml:"store"`
+
+	Access access.Config `yaml:"access"`
+	Policy policy.Config `yaml:"policy"`
+
+	SessionGC sessiongc.Config `yaml:"session_gc"`
+	Purger    purger.Config    `yaml:"purger"`
+	Scanner   scanner.Config   `yaml:"scanner"`
+	Previewer previewer.Config `yaml:"previewer"`
+	Indexer   indexer.Config   `yaml:"indexer"`
+	Relay     relay.Config     `yaml:"relay"`
+
+	Reconciler reconciler.Config `yaml:"reconciler"`
+	Scrubber   scrubber.Config   `yaml:"scrubber"`
 }
 
 func MustLoad() Config {
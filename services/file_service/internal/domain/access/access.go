@@ -0,0 +1,136 @@
+package access
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/user/intuser"
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Action string
+
+const (
+	Upload   Action = "upload"
+	Download Action = "download"
+	Get      Action = "get"
+	Update   Action = "update"
+	Delete   Action = "delete"
+	List     Action = "list"
+)
+
+// Resource - файл, папка или набор файлов, к которому запрашивается доступ.
+// Для папки UploaderID - ее создатель
+type Resource struct {
+	CourseID   string
+	GroupID    string
+	UploaderID string
+}
+
+func FileResource(file *model.File) Resource {
+	return Resource{
+		CourseID:   file.CourseID,
+		GroupID:    file.GroupID,
+		UploaderID: file.UploaderID,
+	}
+}
+
+func FolderResource(folder *model.Folder) Resource {
+	return Resource{
+		CourseID:   folder.CourseID,
+		GroupID:    folder.GroupID,
+		UploaderID: folder.CreatorID,
+	}
+}
+
+type cacheEntry struct {
+	membership *Membership
+	expiresAt  time.Time
+}
+
+// Checker проверяет права вызывающего по членству в курсах и группах.
+// Членство кэшируется на CacheTTL, поэтому изменения в user_service
+// применяются с этой задержкой
+type Checker struct {
+	client pb.UserClient
+	ttl    time.Duration
+	logger logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+func New(logger logger.Logger, client pb.UserClient, cfg Config) *Checker {
+	return &Checker{
+		client: client,
+		ttl:    cfg.CacheTTL,
+		logger: logger.WithFields("layer", "access"),
+		cache:  make(map[string]cacheEntry),
+	}
+}
+
+func (c *Checker) Authorize(ctx context.Context, action Action, res Resource) error {
+	m, err := c.Membership(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !m.Allows(action, res) {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed", action)
+	}
+	return nil
+}
+
+// Membership возвращает членство вызывающего, при необходимости
+// запрашивая его в user_service от имени вызывающего
+func (c *Checker) Membership(ctx context.Context) (*Membership, error) {
+	caller, err := utils.CallerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := caller.ID.String()
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.cache[userID]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.membership, nil
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+caller.Token)
+
+	resp, err := c.client.GetUserAccess(ctx, &pb.GetUserAccessRequest{UserID: userID})
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.PermissionDenied, "user is not registered")
+	}
+	if err != nil {
+		c.logger.Errorf("failed to get access of user %s: %s", userID, err)
+		return nil, status.Error(codes.Unavailable, "access check failed")
+	}
+
+	m := newMembership(userID, resp)
+
+	c.mu.Lock()
+	c.evictExpired(now)
+	c.cache[userID] = cacheEntry{membership: m, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+
+	return m, nil
+}
+
+// evictExpired чистит кэш, вызывается под mu
+func (c *Checker) evictExpired(now time.Time) {
+	for id, entry := range c.cache {
+		if now.After(entry.expiresAt) {
+			delete(c.cache, id)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package access
+
+import "time"
+
+type Config struct {
+	UserServiceAddress string        `validate:"required" yaml:"user_service_address"`
+	CacheTTL           time.Duration `yaml:"cache_ttl" env-default:"30s"`
+}
@@ -0,0 +1,111 @@
+package access
+
+import (
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/user/intuser"
+)
+
+const RoleAdmin = "admin"
+
+// Membership - курсы и группы пользователя по данным user_service
+type Membership struct {
+	UserID string
+	Role   string
+
+	teacherCourses map[string]struct{}
+	courses        map[string]struct{}
+	groups         map[string]struct{}
+}
+
+func newMembership(userID string, resp *pb.GetUserAccessResponse) *Membership {
+	return &Membership{
+		UserID:         userID,
+		Role:           resp.Role,
+		teacherCourses: toSet(resp.TeacherCourseIDs),
+		courses:        toSet(resp.CourseIDs),
+		groups:         toSet(resp.GroupIDs),
+	}
+}
+
+func (m *Membership) IsAdmin() bool {
+	return m.Role == RoleAdmin
+}
+
+// Teaches - пользователь преподает курс
+func (m *Membership) Teaches(courseID string) bool {
+	_, ok := m.teacherCourses[courseID]
+	return courseID != "" && ok
+}
+
+// InCourse - пользователь учится на курсе через одну из своих групп
+func (m *Membership) InCourse(courseID string) bool {
+	_, ok := m.courses[courseID]
+	return courseID != "" && ok
+}
+
+func (m *Membership) InGroup(groupID string) bool {
+	_, ok := m.groups[groupID]
+	return groupID != "" && ok
+}
+
+// CourseIDs возвращает все курсы, где пользователь преподает или учится
+func (m *Membership) CourseIDs() []string {
+	ids := make([]string, 0, len(m.teacherCourses)+len(m.courses))
+	for id := range m.teacherCourses {
+		ids = append(ids, id)
+	}
+	for id := range m.courses {
+		if _, ok := m.teacherCourses[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (m *Membership) GroupIDs() []string {
+	ids := make([]string, 0, len(m.groups))
+	for id := range m.groups {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Allows решает, можно ли выполнить action над ресурсом:
+//   - upload: преподаватель курса; без курса - участник группы;
+//     без курса и группы - личный файл или личная папка владельца
+//   - download, get, list: загрузивший, преподаватель курса,
+//     студент курса или участник группы
+//   - update, delete: загрузивший или преподаватель курса
+func (m *Membership) Allows(action Action, res Resource) bool {
+	if m.IsAdmin() {
+		return true
+	}
+
+	owner := res.UploaderID != "" && res.UploaderID == m.UserID
+	teacher := m.Teaches(res.CourseID)
+	member := m.InCourse(res.CourseID) || m.InGroup(res.GroupID)
+
+	switch action {
+	case Upload:
+		if res.CourseID != "" {
+			return teacher
+		}
+		if res.GroupID != "" {
+			return m.InGroup(res.GroupID)
+		}
+		return res.UploaderID == "" || owner
+	case Download, Get, List:
+		return owner || teacher || member
+	case Update, Delete:
+		return owner || teacher
+	}
+
+	return false
+}
+
+func toSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
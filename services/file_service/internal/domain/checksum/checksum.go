@@ -0,0 +1,89 @@
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Sum - контрольные суммы содержимого в hex: SHA-256 и CRC32C
+// (Castagnoli, 4 байта big-endian)
+type Sum struct {
+	SHA256 string
+	CRC32C string
+}
+
+// Hasher считает обе суммы за один проход
+type Hasher struct {
+	sha256 hash.Hash
+	crc32c hash.Hash32
+}
+
+func New() *Hasher {
+	return &Hasher{
+		sha256: sha256.New(),
+		crc32c: crc32.New(castagnoli),
+	}
+}
+
+func (h *Hasher) Write(p []byte) (int, error) {
+	h.sha256.Write(p)
+	h.crc32c.Write(p)
+	return len(p), nil
+}
+
+func (h *Hasher) Sum() Sum {
+	return Sum{
+		SHA256: hex.EncodeToString(h.sha256.Sum(nil)),
+		CRC32C: hex.EncodeToString(binary.BigEndian.AppendUint32(nil, h.crc32c.Sum32())),
+	}
+}
+
+func Of(data []byte) Sum {
+	h := New()
+	h.Write(data)
+	return h.Sum()
+}
+
+// Read считает суммы reader до конца
+func Read(reader io.Reader) (Sum, error) {
+	h := New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return Sum{}, err
+	}
+	return h.Sum(), nil
+}
+
+// Valid проверяет суммы, присланные клиентом: каждая пуста или
+// hex нужной длины. Возвращает суммы в нижнем регистре
+func Valid(sha, crc string) (Sum, bool) {
+	sum := Sum{SHA256: strings.ToLower(sha), CRC32C: strings.ToLower(crc)}
+	return sum, validHex(sum.SHA256, sha256.Size) && validHex(sum.CRC32C, crc32.Size)
+}
+
+func validHex(s string, size int) bool {
+	if s == "" {
+		return true
+	}
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == size
+}
+
+// Mismatch возвращает название первой суммы, заданной в s и не
+// совпавшей с actual, пусто - совпали. Незаданная в actual сумма
+// (содержимое, сохраненное до появления CRC32C) не сравнивается
+func (s Sum) Mismatch(actual Sum) string {
+	switch {
+	case s.SHA256 != "" && actual.SHA256 != "" && s.SHA256 != actual.SHA256:
+		return "sha256"
+	case s.CRC32C != "" && actual.CRC32C != "" && s.CRC32C != actual.CRC32C:
+		return "crc32c"
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package content
+
+import (
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/preview"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+// Release убирает ссылку на содержимое, объект в хранилище удаляется
+// вместе с последней ссылкой
+func Release(st store.Store, hash string, key string) error {
+	remove := func(key string) error {
+		if err := st.File().Delete(key); err != nil {
+			return err
+		}
+		// Миниатюра без оригинала не нужна, ее остатки найдет сверка хранилища
+		_ = st.File().Delete(preview.Key(key))
+		return nil
+	}
+
+	// Файлы, загруженные до дедупликации, владеют объектом единолично
+	if hash == "" {
+		return remove(key)
+	}
+
+	return st.Blobs().Unlink(key, remove)
+}
+
+// Purge окончательно удаляет файл из корзины и освобождает содержимое
+// всех его версий. Ошибки освобождения только логируются: строки уже
+// удалены, а оставшиеся объекты найдет сверка хранилища
+func Purge(st store.Store, logger logger.Logger, file *model.File) error {
+	versions, err := st.Meta().ListVersions(file.UUID)
+	if err != nil {
+		return err
+	}
+
+	if err := st.Meta().Purge(file.UUID); err != nil {
+		return err
+	}
+
+	// У файлов без версий ссылку держит сама строка файла
+	if len(versions) == 0 {
+		versions = append(versions, &model.FileVersion{Hash: file.Hash, StorageKey: file.StorageKey})
+	}
+
+	for _, v := range versions {
+		if err := Release(st, v.Hash, v.StorageKey); err != nil {
+			logger.Warnf("failed to release content of version %d of %s: %s", v.Version, file.UUID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package envelope
+
+type Config struct {
+	// Enabled - шифровать новое содержимое. Уже зашифрованное читается,
+	// пока заданы мастер-ключи, даже если шифрование выключено
+	Enabled bool `yaml:"enabled" env-default:"false"`
+
+	// MasterKeyID - мастер-ключ, которым оборачиваются новые ключи
+	// курсов и к которому их приводит ротация
+	MasterKeyID string `yaml:"master_key_id"`
+
+	// MasterKeys - мастер-ключи по ID, 32 байта в base64. Прежний ключ
+	// можно убрать из списка только после ротации
+	MasterKeys map[string]string `yaml:"master_keys"`
+}
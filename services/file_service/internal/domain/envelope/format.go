@@ -0,0 +1,147 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// Формат зашифрованного объекта: заголовок и чанки по ChunkSize байт
+// открытого текста, последний может быть короче (у пустого объекта
+// один пустой чанк). Каждый объект шифруется своим ключом, выведенным
+// из ключа курса и случайной соли заголовка. Nonce чанка - его номер
+// и признак последнего чанка, поэтому перестановка и усечение чанков
+// обнаруживаются при расшифровке
+const (
+	ChunkSize = 64 << 10
+	// Overhead - тег аутентификации GCM в каждом чанке
+	Overhead = 16
+
+	saltSize = 32
+	// HeaderSize - размер заголовка: magic, ID ключа курса и соль
+	HeaderSize = len(magic) + 8 + saltSize
+)
+
+// magic отличает зашифрованные объекты от записанных до включения шифрования
+const magic = "FSENC\x00v1"
+
+var ErrCorrupted = errors.New("encrypted content is corrupted")
+
+// Header - заголовок зашифрованного объекта
+type Header struct {
+	KeyID uint64
+	Salt  [saltSize]byte
+}
+
+// NewHeader возвращает заголовок со свежей солью
+func NewHeader(keyID uint64) (Header, error) {
+	h := Header{KeyID: keyID}
+	_, err := rand.Read(h.Salt[:])
+	return h, err
+}
+
+func (h Header) Marshal() []byte {
+	b := make([]byte, 0, HeaderSize)
+	b = append(b, magic...)
+	b = binary.BigEndian.AppendUint64(b, h.KeyID)
+	return append(b, h.Salt[:]...)
+}
+
+// ParseHeader разбирает заголовок, ok - false, если b не начинается
+// с заголовка, то есть объект не зашифрован
+func ParseHeader(b []byte) (h Header, ok bool) {
+	if len(b) < HeaderSize || !bytes.HasPrefix(b, []byte(magic)) {
+		return h, false
+	}
+
+	b = b[len(magic):]
+	h.KeyID = binary.BigEndian.Uint64(b)
+	copy(h.Salt[:], b[8:])
+	return h, true
+}
+
+// SealedSize - размер зашифрованного объекта с заголовком
+func SealedSize(size int64) int64 {
+	return int64(HeaderSize) + size + Chunks(size)*Overhead
+}
+
+// PlainSize - размер открытого текста объекта с заголовком размером
+// sealed, ok - false, если такого размера не бывает
+func PlainSize(sealed int64) (size int64, ok bool) {
+	sealed -= int64(HeaderSize)
+	if sealed < Overhead {
+		return 0, false
+	}
+
+	chunks := (sealed + ChunkSize + Overhead - 1) / (ChunkSize + Overhead)
+	size = sealed - chunks*Overhead
+	if size < (chunks-1)*ChunkSize {
+		return 0, false
+	}
+	return size, true
+}
+
+// Chunks - число чанков в объекте размером size
+func Chunks(size int64) int64 {
+	if size == 0 {
+		return 1
+	}
+	return (size + ChunkSize - 1) / ChunkSize
+}
+
+// Sealer шифрует и расшифровывает чанки одного объекта
+type Sealer struct {
+	aead cipher.AEAD
+	aad  []byte
+}
+
+// NewSealer выводит ключ объекта из ключа курса и соли заголовка
+func NewSealer(h Header, dataKey []byte) (*Sealer, error) {
+	key, err := hkdf.Key(sha256.New, dataKey, h.Salt[:], "fileshare object key", 32)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealer{aead: aead, aad: h.Marshal()}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(index uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, index)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// Seal дописывает к dst зашифрованный чанк index
+func (s *Sealer) Seal(dst []byte, plain []byte, index uint64, final bool) []byte {
+	return s.aead.Seal(dst, chunkNonce(index, final), plain, s.aad)
+}
+
+// Open расшифровывает чанк index на месте
+func (s *Sealer) Open(sealed []byte, index uint64, final bool) ([]byte, error) {
+	plain, err := s.aead.Open(sealed[:0], chunkNonce(index, final), sealed, s.aad)
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+	return plain, nil
+}
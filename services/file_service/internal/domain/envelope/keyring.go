@@ -0,0 +1,214 @@
+package envelope
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+const dataKeySize = 32
+
+// Keyring выдает ключи курсов, разворачивая их мастер-ключами.
+// Развернутые ключи кешируются на время жизни процесса
+type Keyring struct {
+	keys    store.KeyRepository
+	masters map[string]cipher.AEAD
+	current string
+	enabled bool
+
+	mu       sync.RWMutex
+	byID     map[uint64][]byte
+	byCourse map[string]uint64
+}
+
+// New возвращает nil, если мастер-ключи не заданы: шифрования нет
+func New(cfg Config, keys store.KeyRepository) (*Keyring, error) {
+	if len(cfg.MasterKeys) == 0 {
+		if cfg.Enabled {
+			return nil, errors.New("encryption is enabled, but no master keys are configured")
+		}
+		return nil, nil
+	}
+
+	masters := make(map[string]cipher.AEAD, len(cfg.MasterKeys))
+	for id, encoded := range cfg.MasterKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("master key %q must be 32 bytes in base64", id)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		masters[id] = aead
+	}
+
+	if _, ok := masters[cfg.MasterKeyID]; !ok {
+		return nil, fmt.Errorf("master key %q is not configured", cfg.MasterKeyID)
+	}
+
+	return &Keyring{
+		keys:     keys,
+		masters:  masters,
+		current:  cfg.MasterKeyID,
+		enabled:  cfg.Enabled,
+		byID:     make(map[uint64][]byte),
+		byCourse: make(map[string]uint64),
+	}, nil
+}
+
+// Enabled сообщает, шифровать ли новое содержимое
+func (k *Keyring) Enabled() bool {
+	return k.enabled
+}
+
+// CourseKey возвращает ключ курса, создавая его при первой записи
+func (k *Keyring) CourseKey(courseID string) (uint64, []byte, error) {
+	k.mu.RLock()
+	id, ok := k.byCourse[courseID]
+	key := k.byID[id]
+	k.mu.RUnlock()
+
+	if ok {
+		return id, key, nil
+	}
+
+	dataKey, err := k.keys.GetOrCreate(courseID, func() (*model.DataKey, error) {
+		key := make([]byte, dataKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		return k.wrap(courseID, key)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	key, err = k.unwrap(dataKey)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	k.remember(dataKey, key)
+	return dataKey.ID, key, nil
+}
+
+// Key возвращает ключ курса по ID из заголовка объекта
+func (k *Keyring) Key(id uint64) ([]byte, error) {
+	k.mu.RLock()
+	key, ok := k.byID[id]
+	k.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	dataKey, err := k.keys.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("data key %d: %w", id, err)
+	}
+
+	key, err = k.unwrap(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k.remember(dataKey, key)
+	return key, nil
+}
+
+func (k *Keyring) remember(dataKey *model.DataKey, key []byte) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.byID[dataKey.ID] = key
+	k.byCourse[dataKey.CourseID] = dataKey.ID
+}
+
+// wrap шифрует ключ текущим мастер-ключом. Курс входит в AAD,
+// поэтому обертку нельзя переставить в строку другого курса
+func (k *Keyring) wrap(courseID string, key []byte) (*model.DataKey, error) {
+	master := k.masters[k.current]
+
+	nonce := make([]byte, master.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &model.DataKey{
+		CourseID:    courseID,
+		Wrapped:     master.Seal(nonce, nonce, key, []byte(courseID)),
+		MasterKeyID: k.current,
+	}, nil
+}
+
+func (k *Keyring) unwrap(dataKey *model.DataKey) ([]byte, error) {
+	master, ok := k.masters[dataKey.MasterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("data key %d is wrapped by unknown master key %q", dataKey.ID, dataKey.MasterKeyID)
+	}
+
+	size := master.NonceSize()
+	if len(dataKey.Wrapped) < size {
+		return nil, fmt.Errorf("data key %d is malformed", dataKey.ID)
+	}
+
+	key, err := master.Open(nil, dataKey.Wrapped[:size], dataKey.Wrapped[size:], []byte(dataKey.CourseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key %d: %w", dataKey.ID, err)
+	}
+	return key, nil
+}
+
+// Rotate переоборачивает текущим мастер-ключом ключи курсов,
+// обернутые прежними, по batchSize за запрос. Содержимое не
+// перешифровывается: оно зависит только от самих ключей курсов
+func (k *Keyring) Rotate(batchSize int) (int, error) {
+	var rotated int
+	var after uint64
+
+	for {
+		keys, err := k.keys.ListNotWrappedBy(k.current, after, batchSize)
+		if err != nil {
+			return rotated, err
+		}
+		if len(keys) == 0 {
+			return rotated, nil
+		}
+
+		for _, dataKey := range keys {
+			after = dataKey.ID
+
+			key, err := k.unwrap(dataKey)
+			if err != nil {
+				return rotated, err
+			}
+
+			wrapped, err := k.wrap(dataKey.CourseID, key)
+			if err != nil {
+				return rotated, err
+			}
+
+			oldMaster := dataKey.MasterKeyID
+			dataKey.Wrapped = wrapped.Wrapped
+			dataKey.MasterKeyID = wrapped.MasterKeyID
+
+			err = k.keys.Rewrap(dataKey, oldMaster)
+			if errors.Is(err, store.ErrConflict) {
+				// Ключ уже переобернут параллельной ротацией
+				continue
+			}
+			if err != nil {
+				return rotated, err
+			}
+			rotated++
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+)
+
+// EventPublisher доставляет события из outbox получателям. Publish
+// возвращает ошибку, если доставка не подтверждена: событие останется
+// в outbox и будет отправлено повторно
+type EventPublisher interface {
+	Publish(ctx context.Context, event *model.OutboxEvent) error
+	Close() error
+}
+
+// Handler - получатель событий внутри процесса
+type Handler func(ctx context.Context, event *model.OutboxEvent) error
+
+// Local публикует события внутри процесса: пишет их в лог и вызывает
+// подписчиков. Подходит для разработки и окружений без брокера
+type Local struct {
+	logger logger.Logger
+
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewLocal(logger logger.Logger) *Local {
+	return &Local{
+		logger: logger.WithFields("layer", "local event publisher"),
+	}
+}
+
+// Subscribe добавляет получателя. Ошибка получателя считается
+// недоставкой, и событие будет отправлено всем получателям повторно
+func (l *Local) Subscribe(handler Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.handlers = append(l.handlers, handler)
+}
+
+func (l *Local) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	l.logger.Infof("event %s %s: %s", event.UUID, event.Type, event.Payload)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, handler := range l.handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) Close() error {
+	return nil
+}
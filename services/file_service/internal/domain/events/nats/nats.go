@@ -0,0 +1,233 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+)
+
+// Publisher публикует события по текстовому протоколу NATS. После
+// каждого сообщения отправляется PING: PONG приходит, только когда
+// сервер обработал все предыдущие команды, это и есть подтверждение.
+// Если сервер поддерживает заголовки, UUID события уходит в Nats-Msg-Id,
+// и JetStream отбрасывает повторы. Соединение открывается лениво
+// и пересоздается после любой ошибки
+type Publisher struct {
+	cfg Config
+
+	mu         sync.Mutex
+	conn       net.Conn
+	reader     *bufio.Reader
+	headers    bool
+	maxPayload int64
+}
+
+// serverInfo - нужные поля INFO, которым сервер начинает соединение
+type serverInfo struct {
+	Headers      bool  `json:"headers"`
+	MaxPayload   int64 `json:"max_payload"`
+	AuthRequired bool  `json:"auth_required"`
+}
+
+type connectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	Headers  bool   `json:"headers"`
+
+	User     string `json:"user,omitempty"`
+	Password string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+func New(cfg Config) *Publisher {
+	return &Publisher{cfg: cfg}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			p.reset()
+			return fmt.Errorf("nats connect failed: %w", err)
+		}
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		p.reset()
+		return fmt.Errorf("nats publish failed: %w", err)
+	}
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+func (p *Publisher) connect(ctx context.Context) error {
+	u, err := url.Parse(p.cfg.URL)
+	if err != nil {
+		return err
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	dialer := net.Dialer{Timeout: p.cfg.Timeout}
+	p.conn, err = dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	p.reader = bufio.NewReader(p.conn)
+
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+
+	line, err := p.readLine()
+	if err != nil {
+		return err
+	}
+
+	payload, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+
+	var info serverInfo
+	if err := json.Unmarshal([]byte(payload), &info); err != nil {
+		return fmt.Errorf("malformed INFO: %w", err)
+	}
+	p.headers = info.Headers
+	p.maxPayload = info.MaxPayload
+
+	opts := connectOptions{
+		Name:     "file_service",
+		Lang:     "go",
+		Version:  "1.0.0",
+		Protocol: 1,
+		Headers:  info.Headers,
+		User:     p.cfg.User,
+		Password: p.cfg.Password,
+		Token:    p.cfg.Token,
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts.User, opts.Password = u.User.Username(), password
+		} else {
+			opts.Token = u.User.Username()
+		}
+	}
+
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+
+	// Ошибка авторизации приходит вместо PONG
+	return p.awaitPong()
+}
+
+func (p *Publisher) publish(ctx context.Context, event *model.OutboxEvent) error {
+	if p.maxPayload > 0 && int64(len(event.Payload)) > p.maxPayload {
+		return fmt.Errorf("event %s exceeds max payload of %d bytes", event.UUID, p.maxPayload)
+	}
+
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+
+	subject := p.cfg.SubjectPrefix + "." + event.Type
+
+	var msg []byte
+	if p.headers {
+		header := fmt.Sprintf("NATS/1.0\r\nNats-Msg-Id: %s\r\n\r\n", event.UUID)
+		msg = fmt.Appendf(msg, "HPUB %s %d %d\r\n%s",
+			subject, len(header), len(header)+len(event.Payload), header)
+	} else {
+		msg = fmt.Appendf(msg, "PUB %s %d\r\n", subject, len(event.Payload))
+	}
+	msg = append(msg, event.Payload...)
+	msg = append(msg, "\r\nPING\r\n"...)
+
+	if _, err := p.conn.Write(msg); err != nil {
+		return err
+	}
+
+	return p.awaitPong()
+}
+
+// awaitPong читает ответы до PONG, отвечая на PING сервера
+func (p *Publisher) awaitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(strings.Trim(strings.TrimPrefix(line, "-ERR "), "'"))
+		}
+		// +OK и повторные INFO не требуют ответа
+	}
+}
+
+func (p *Publisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *Publisher) setDeadline(ctx context.Context) error {
+	deadline := time.Now().Add(p.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	return p.conn.SetDeadline(deadline)
+}
+
+func (p *Publisher) reset() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn = nil
+	p.reader = nil
+}
@@ -0,0 +1,245 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats-server/v2/test"
+)
+
+func runServer(t *testing.T, opts server.Options) *server.Server {
+	t.Helper()
+
+	srv := test.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func serverOptions() server.Options {
+	opts := test.DefaultTestOptions
+	opts.Port = -1
+	return opts
+}
+
+func serverURL(srv *server.Server) string {
+	return "nats://" + srv.Addr().String()
+}
+
+func testEvent(id string) *model.OutboxEvent {
+	return &model.OutboxEvent{
+		UUID:    id,
+		Type:    model.EventFileUploaded,
+		Payload: []byte(`{"uuid":"` + id + `"}`),
+	}
+}
+
+// message - сообщение, полученное подписчиком
+type message struct {
+	subject string
+	header  string
+	payload string
+}
+
+// subscriber - клиент NATS на текстовом протоколе, подписанный на subject
+type subscriber struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func subscribe(t *testing.T, srv *server.Server, subject string) *subscriber {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", srv.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	s := &subscriber{conn: conn, reader: bufio.NewReader(conn)}
+	if _, err := s.readLine(); err != nil {
+		t.Fatalf("read INFO: %v", err)
+	}
+
+	_, err = fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"headers\":true,\"protocol\":1}\r\nSUB %s 1\r\nPING\r\n", subject)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if line, err := s.readLine(); err != nil || line != "PONG" {
+		t.Fatalf("subscribe: got %q, %v", line, err)
+	}
+
+	return s
+}
+
+func (s *subscriber) readLine() (string, error) {
+	if err := s.conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return "", err
+	}
+	line, err := s.reader.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func (s *subscriber) next(t *testing.T) message {
+	t.Helper()
+
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			t.Fatalf("read message: %v", err)
+		}
+
+		if line == "PING" {
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				t.Fatalf("pong: %v", err)
+			}
+			continue
+		}
+
+		var msg message
+		var sid string
+		var headerSize, totalSize int
+
+		switch {
+		case strings.HasPrefix(line, "HMSG "):
+			_, err = fmt.Sscanf(line, "HMSG %s %s %d %d", &msg.subject, &sid, &headerSize, &totalSize)
+		case strings.HasPrefix(line, "MSG "):
+			_, err = fmt.Sscanf(line, "MSG %s %s %d", &msg.subject, &sid, &totalSize)
+		default:
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parse %q: %v", line, err)
+		}
+
+		body := make([]byte, totalSize+2)
+		if _, err := io.ReadFull(s.reader, body); err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+
+		msg.header = string(body[:headerSize])
+		msg.payload = string(body[headerSize:totalSize])
+		return msg
+	}
+}
+
+func TestPublish(t *testing.T) {
+	srv := runServer(t, serverOptions())
+	sub := subscribe(t, srv, "fileshare.>")
+
+	p := New(Config{URL: serverURL(srv), SubjectPrefix: "fileshare", Timeout: 5 * time.Second})
+	defer p.Close()
+
+	for _, id := range []string{"event-1", "event-2"} {
+		event := testEvent(id)
+		if err := p.Publish(context.Background(), event); err != nil {
+			t.Fatalf("publish %s: %v", id, err)
+		}
+
+		msg := sub.next(t)
+		if msg.subject != "fileshare."+model.EventFileUploaded {
+			t.Errorf("subject = %q", msg.subject)
+		}
+		if !strings.Contains(msg.header, "Nats-Msg-Id: "+id+"\r\n") {
+			t.Errorf("header %q has no Nats-Msg-Id %s", msg.header, id)
+		}
+		if msg.payload != string(event.Payload) {
+			t.Errorf("payload = %q, want %q", msg.payload, event.Payload)
+		}
+	}
+}
+
+func TestPublishMaxPayload(t *testing.T) {
+	opts := serverOptions()
+	opts.MaxPayload = 16
+	srv := runServer(t, opts)
+
+	p := New(Config{URL: serverURL(srv), SubjectPrefix: "fileshare", Timeout: 5 * time.Second})
+	defer p.Close()
+
+	event := testEvent("event-1")
+	event.Payload = []byte(strings.Repeat("x", 17))
+
+	err := p.Publish(context.Background(), event)
+	if err == nil || !strings.Contains(err.Error(), "max payload") {
+		t.Fatalf("publish = %v, want max payload error", err)
+	}
+}
+
+func TestPublishAuth(t *testing.T) {
+	opts := serverOptions()
+	opts.Username = "file_service"
+	opts.Password = "secret"
+	srv := runServer(t, opts)
+
+	address := srv.Addr().String()
+
+	tests := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{name: "no credentials", cfg: Config{URL: "nats://" + address}},
+		{name: "wrong password", cfg: Config{URL: "nats://" + address, User: "file_service", Password: "wrong"}},
+		{name: "config credentials", cfg: Config{URL: "nats://" + address, User: "file_service", Password: "secret"}, ok: true},
+		{name: "url credentials", cfg: Config{URL: "nats://file_service:secret@" + address}, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.SubjectPrefix = "fileshare"
+			tt.cfg.Timeout = 5 * time.Second
+
+			p := New(tt.cfg)
+			defer p.Close()
+
+			err := p.Publish(context.Background(), testEvent("event-1"))
+			if tt.ok && err != nil {
+				t.Fatalf("publish: %v", err)
+			}
+			if !tt.ok && (err == nil || !strings.Contains(err.Error(), "Authorization Violation")) {
+				t.Fatalf("publish = %v, want authorization error", err)
+			}
+		})
+	}
+}
+
+func TestPublishReconnect(t *testing.T) {
+	opts := serverOptions()
+	srv := runServer(t, opts)
+
+	// Перезапущенный сервер должен слушать тот же порт
+	opts.Port = srv.Addr().(*net.TCPAddr).Port
+
+	p := New(Config{URL: serverURL(srv), SubjectPrefix: "fileshare", Timeout: time.Second})
+	defer p.Close()
+
+	if err := p.Publish(context.Background(), testEvent("event-1")); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	srv.Shutdown()
+	srv.WaitForShutdown()
+
+	if err := p.Publish(context.Background(), testEvent("event-2")); err == nil {
+		t.Fatal("publish to a stopped server succeeded")
+	}
+
+	srv = runServer(t, opts)
+	sub := subscribe(t, srv, "fileshare.>")
+
+	if err := p.Publish(context.Background(), testEvent("event-2")); err != nil {
+		t.Fatalf("publish after restart: %v", err)
+	}
+
+	if msg := sub.next(t); !strings.Contains(msg.header, "Nats-Msg-Id: event-2\r\n") {
+		t.Errorf("header %q has no Nats-Msg-Id event-2", msg.header)
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Blob - содержимое в хранилище, общее для файлов курса с одинаковым
+// SHA-256. Курс входит в ContentKey, поэтому одинаковое содержимое
+// разных курсов - разные blob, зашифрованные ключами своих курсов.
+// StorageKey у каждого нового blob свой: объект удаленного blob
+// удаляется после фиксации и не задевает blob, созданный заново
+type Blob struct {
+	ID         uint   `gorm:"primarykey"`
+	Hash       string `gorm:"index:idx_blobs_content_hash"`
+	CRC32C     string `gorm:"column:crc32c;default:''"`
+	ContentKey string `gorm:"uniqueIndex"`
+	StorageKey string `gorm:"uniqueIndex"`
+	Size       int64
+	RefCount   int64
+
+	// Missing - объект пропал из хранилища, следующий Link загрузит его заново
+	Missing bool `gorm:"default:false"`
+
+	// VerifiedAt - когда scrubber последний раз сверил объект с суммами
+	VerifiedAt *time.Time `gorm:"index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// DataKey - ключ шифрования содержимого курса. Хранится обернутым
+// мастер-ключом MasterKeyID, поэтому ротация мастер-ключа
+// переоборачивает строки и не трогает содержимое.
+// Дедупликация идет внутри курса (см. Blob), поэтому каждый объект
+// зашифрован ключом того курса, которому принадлежит. При копировании
+// и переносе файла в другой курс содержимое перешифровывается
+type DataKey struct {
+	ID          uint64 `gorm:"primarykey"`
+	CourseID    string `gorm:"uniqueIndex"` // пусто - содержимое вне курсов
+	Wrapped     []byte
+	MasterKeyID string `gorm:"index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
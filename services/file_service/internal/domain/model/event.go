@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// Типы событий о файлах
+const (
+	EventFileUploaded        = "file.uploaded"
+	EventFileVersionAdded    = "file.version_added"
+	EventFileVersionRestored = "file.version_restored"
+	EventFileUpdated         = "file.updated" // имя, тип, теги или метаданные
+	EventFileMoved           = "file.moved"
+	EventFileDeleted         = "file.deleted" // перенесен в корзину
+	EventFileRestored        = "file.restored"
+	EventFilePurged          = "file.purged"
+	EventFileScanned         = "file.scanned"
+)
+
+// OutboxEvent - событие, записанное в одной транзакции с изменением
+// файла. Relay публикует события в порядке ID, доставка - at-least-once,
+// получатели отбрасывают повторы по UUID
+type OutboxEvent struct {
+	ID       uint64 `gorm:"primarykey"`
+	UUID     string `gorm:"uniqueIndex"`
+	Type     string
+	FileUUID string `gorm:"index"`
+	Payload  []byte // FileEvent в JSON
+
+	CreatedAt   time.Time
+	PublishedAt *time.Time `gorm:"index"`
+
+	// Неудачные попытки публикации
+	Attempts  int
+	LastError string
+}
+
+// FileEvent - тело события: состояние файла после изменения
+type FileEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	FileID     string `json:"file_id"`
+	Name       string `json:"name"`
+	MimeType   string `json:"mime_type"`
+	Size       int64  `json:"size"`
+	Version    int32  `json:"version"`
+	Sha256     string `json:"sha256,omitempty"`
+	CRC32C     string `json:"crc32c,omitempty"`
+	UploaderID string `json:"uploader_id"`
+	CourseID   string `json:"course_id,omitempty"`
+	GroupID    string `json:"group_id,omitempty"`
+	FolderID   string `json:"folder_id,omitempty"`
+	ScanStatus string `json:"scan_status"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Folder - папка внутри курса или группы, ParentID пустой у папок корня.
+// Подпапки и файлы всегда принадлежат тем же курсу и группе
+type Folder struct {
+	ID       uint   `gorm:"primarykey"`
+	UUID     string `gorm:"uniqueIndex"`
+	Name     string
+	ParentID string `gorm:"index"`
+
+	CourseID  string `gorm:"index"`
+	GroupID   string `gorm:"index"`
+	CreatorID string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
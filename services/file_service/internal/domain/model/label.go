@@ -0,0 +1,16 @@
+package model
+
+// FileTag - метка файла, например "lecture" или "week-3"
+type FileTag struct {
+	ID       uint   `gorm:"primarykey"`
+	FileUUID string `gorm:"uniqueIndex:idx_file_tag"`
+	Tag      string `gorm:"uniqueIndex:idx_file_tag;index"`
+}
+
+// FileAttribute - пара произвольных метаданных файла
+type FileAttribute struct {
+	ID       uint   `gorm:"primarykey"`
+	FileUUID string `gorm:"uniqueIndex:idx_file_attribute"`
+	Key      string `gorm:"uniqueIndex:idx_file_attribute"`
+	Value    string
+}
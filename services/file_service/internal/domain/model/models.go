@@ -17,13 +17,37 @@ type File struct {
 
 	CourseID string
 	GroupID  string
+	FolderID string `gorm:"index"`
 
+	// Hash - SHA-256 содержимого, ключ общего Blob. CRC32C в hex пуст
+	// у содержимого, загруженного раньше, пока его не перепроверит scrubber
+	Hash       string `gorm:"index"`
+	CRC32C     string `gorm:"column:crc32c;default:''"`
 	StorageKey string
 	CreatedAt  time.Time
+
+	// ContentMissing - объекта StorageKey нет в хранилище, выставляется сверкой
+	ContentMissing bool `gorm:"default:false"`
+
+	// CurrentVersion - номер FileVersion, содержимое которой
+	// отражено в полях выше
+	CurrentVersion int32
+
+	// ScanResult, PreviewState и TextState относятся к текущему содержимому
+	ScanResult
+	PreviewState string `gorm:"index;default:pending"`
+	TextState    string `gorm:"index;default:pending"`
+
+	// Tags и Metadata хранятся в FileTag и FileAttribute
+	// и заполняются хранилищем при чтении файла
+	Tags     []string          `gorm:"-"`
+	Metadata map[string]string `gorm:"-"`
 }
 
 type StorageObjectInfo struct {
+	Key          string
 	Size         int64
 	ContentType  string
+	ETag         string
 	LastModified time.Time
 }
@@ -0,0 +1,11 @@
+package model
+
+// Состояния миниатюры текущего содержимого файла
+const (
+	PreviewPending = "pending"
+	PreviewReady   = "ready"
+	// PreviewNone - формат не поддерживается или содержимое не читается
+	PreviewNone = "none"
+	// PreviewFailed - содержимое пропало, повреждено или не разбирается
+	PreviewFailed = "failed"
+)
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type FileSortField int
+
+const (
+	SortByCreatedAt FileSortField = iota
+	SortByName
+	SortBySize
+)
+
+// FileQuery описывает выборку файлов, пустые поля не фильтруют
+type FileQuery struct {
+	UploaderID string
+	CourseID   string
+	GroupID    string
+	MimeType   string
+
+	// ByFolder - выбирать только файлы папки FolderID. Пустой FolderID -
+	// корень, тогда CourseID и GroupID сравниваются точно, включая пустые
+	ByFolder bool
+	FolderID string
+
+	// FolderIDs - выбирать только файлы этих папок
+	FolderIDs []string
+
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	// Файл должен иметь все Tags и все пары Metadata
+	Tags     []string
+	Metadata map[string]string
+
+	// Trashed - выбирать только файлы в корзине
+	Trashed bool
+
+	SortBy FileSortField
+	Desc   bool
+
+	Offset int
+	Limit  int
+}
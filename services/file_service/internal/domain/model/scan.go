@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Статусы проверки содержимого на вредоносное ПО
+const (
+	ScanPending  = "pending_scan"
+	ScanClean    = "clean"
+	ScanInfected = "infected"
+	// ScanFailed - сканер отказался проверять содержимое,
+	// например из-за превышения его лимита размера
+	ScanFailed = "scan_failed"
+)
+
+// ScanResult - результат проверки содержимого, общий для файла и версии.
+// Скачивать можно только содержимое со статусом ScanClean
+type ScanResult struct {
+	ScanStatus    string `gorm:"index;default:pending_scan"`
+	ScanSignature string
+	ScannedAt     *time.Time
+}
+
+// ScanTarget - содержимое, ожидающее проверки. Один ключ хранилища
+// может принадлежать нескольким файлам и версиям, результат
+// записывается всем сразу
+type ScanTarget struct {
+	StorageKey string
+	Size       int64
+}
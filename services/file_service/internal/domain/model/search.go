@@ -0,0 +1,58 @@
+package model
+
+// Состояния извлечения текста текущего содержимого файла для поиска
+const (
+	TextPending = "pending"
+	TextReady   = "ready"
+	// TextNone - формат не поддерживается или текста в нем нет
+	TextNone = "none"
+	// TextFailed - содержимое пропало, повреждено или не разбирается
+	TextFailed = "failed"
+)
+
+// FileText - текст, извлеченный из содержимого. Хранится по ключу
+// хранилища, чтобы одинаковое содержимое разбиралось один раз
+type FileText struct {
+	StorageKey string `gorm:"primaryKey"`
+	Content    string
+}
+
+// SearchDocument - поисковый документ файла: имя и извлеченный текст.
+// Document пересобирается хранилищем при изменении файла и не читается
+type SearchDocument struct {
+	FileUUID string `gorm:"primaryKey"`
+	Document string `gorm:"type:tsvector;index:,type:gin;->:false"`
+}
+
+// SearchQuery - полнотекстовый поиск. Пустые фильтры не применяются
+type SearchQuery struct {
+	Text string
+
+	CourseID string
+	GroupID  string
+	MimeType string
+
+	// Без AllAccess видны только свои файлы и файлы курсов и групп вызывающего
+	AllAccess bool
+	UserID    string
+	CourseIDs []string
+	GroupIDs  []string
+
+	Offset int
+	Limit  int
+}
+
+// SearchHit - найденный файл. В NameHighlight и Snippet совпадения
+// обрамлены символами HighlightStart и HighlightStop
+type SearchHit struct {
+	File
+
+	Rank          float32
+	NameHighlight string
+	Snippet       string
+}
+
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
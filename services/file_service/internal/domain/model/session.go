@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UploadSession - незавершенная загрузка, собираемая по частям
+// через multipart upload в MinIO, либо загрузка по presigned ссылке,
+// ожидающая подтверждения (Presigned)
+type UploadSession struct {
+	gorm.Model
+
+	UUID       string `gorm:"uniqueIndex"`
+	UploaderID string
+
+	Filename string
+	MimeType string
+	CourseID string
+	GroupID  string
+
+	FolderID string
+
+	// FileID - файл, новой версией которого станет загрузка
+	FileID string
+
+	// Size - заявленный размер файла, Offset - сколько байт уже принято
+	Size   int64
+	Offset int64
+
+	// SHA256 и CRC32C - суммы, заявленные клиентом, сверяются
+	// с загруженным содержимым при завершении
+	SHA256 string `gorm:"column:sha256"`
+	CRC32C string `gorm:"column:crc32c"`
+
+	StorageKey string
+	UploadID   string
+	Presigned  bool
+
+	ExpiresAt time.Time `gorm:"index"`
+}
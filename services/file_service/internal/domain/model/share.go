@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// ShareLink - ссылка на файл для скачивания без авторизации
+type ShareLink struct {
+	ID       uint   `gorm:"primarykey"`
+	UUID     string `gorm:"uniqueIndex"`
+	FileUUID string `gorm:"index"`
+
+	// Хранится только SHA-256 токена, сам токен известен лишь
+	// при создании ссылки
+	Token     string `gorm:"-"`
+	TokenHash string `gorm:"uniqueIndex"`
+
+	// Ограничения необязательны: nil, пустой хеш и 0 их отключают
+	ExpiresAt    *time.Time
+	PasswordHash string
+	MaxDownloads int32
+
+	// Лимит расходуется отданными байтами, чтобы его нельзя было обойти
+	// запросами по Range: Downloads - число целых файлов в BytesServed
+	Downloads   int32
+	BytesServed int64
+
+	// Проверки пароля в окне, которое заканчивается в PasswordWindowEnd.
+	// Попытка занимается до проверки и возвращается, если пароль верен
+	PasswordAttempts  int32
+	PasswordWindowEnd *time.Time
+
+	CreatorID string
+	CreatedAt time.Time
+}
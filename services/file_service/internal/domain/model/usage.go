@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type UsageScope string
+
+const (
+	UsageScopeUser   UsageScope = "user"
+	UsageScopeCourse UsageScope = "course"
+)
+
+// Usage - объем, занятый версиями файлов загрузившего или курса.
+// Учитывается логический размер: общие blob засчитываются каждому файлу
+type Usage struct {
+	ID      uint       `gorm:"primarykey"`
+	Scope   UsageScope `gorm:"uniqueIndex:idx_usage_owner"`
+	OwnerID string     `gorm:"uniqueIndex:idx_usage_owner"`
+	Bytes   int64
+
+	// Limit берется из конфигурации, 0 - без ограничения
+	Limit int64 `gorm:"-"`
+
+	UpdatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// FileVersion - ревизия содержимого файла, номера идут с 1
+type FileVersion struct {
+	ID       uint   `gorm:"primarykey"`
+	FileUUID string `gorm:"uniqueIndex:idx_file_version"`
+	Version  int32  `gorm:"uniqueIndex:idx_file_version"`
+
+	MimeType   string
+	Size       int64
+	Hash       string
+	CRC32C     string `gorm:"column:crc32c;default:''"`
+	StorageKey string
+	UploaderID string
+
+	CreatedAt time.Time
+
+	ScanResult
+	ContentMissing bool `gorm:"default:false"`
+}
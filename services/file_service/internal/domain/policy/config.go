@@ -0,0 +1,24 @@
+package policy
+
+// Config - политика содержимого по умолчанию и ее переопределения по курсам
+type Config struct {
+	Default Rules            `yaml:"default"`
+	Courses map[string]Rules `yaml:"courses"`
+}
+
+// Rules - ограничения на загружаемые файлы. Правила курса заменяют
+// разрешенные типы, расширения и размер по умолчанию, если заданы,
+// а списки запрещенного дополняют списки по умолчанию
+type Rules struct {
+	// Разрешенные MIME-типы, допускается маска вида image/*.
+	// Если заданы типы или расширения, файл должен подойти хотя бы под одно
+	AllowedTypes      []string `yaml:"allowed_types"`
+	AllowedExtensions []string `yaml:"allowed_extensions"`
+
+	// Максимальный размер файла в байтах, 0 - без ограничения
+	MaxSize int64 `yaml:"max_size" env-default:"0"`
+
+	// Запрещенные типы и расширения, по умолчанию - исполняемые файлы
+	BlockedTypes      []string `yaml:"blocked_types" env-default:"application/vnd.microsoft.portable-executable,application/x-executable,application/x-mach-binary,text/x-shellscript"`
+	BlockedExtensions []string `yaml:"blocked_extensions" env-default:".exe,.dll,.com,.scr,.msi,.bat,.cmd,.ps1,.vbs,.sh,.jar,.apk,.app,.bin"`
+}
@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// SniffLen - сколько первых байт содержимого нужно Detect
+const SniffLen = 512
+
+// Сигнатуры, которые не различает http.DetectContentType
+var signatures = []struct {
+	magic    []byte
+	mimeType string
+}{
+	{[]byte("MZ"), "application/vnd.microsoft.portable-executable"},
+	{[]byte("\x7fELF"), "application/x-executable"},
+	{[]byte("\xfe\xed\xfa\xce"), "application/x-mach-binary"},
+	{[]byte("\xfe\xed\xfa\xcf"), "application/x-mach-binary"},
+	{[]byte("\xce\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("\xcf\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("#!"), "text/x-shellscript"},
+	{[]byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1"), "application/x-ole-storage"},
+}
+
+// Форматы-контейнеры, которые по содержимому неотличимы от zip или OLE
+var containerTypes = map[string]map[string]string{
+	"application/zip": {
+		".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		".odt":  "application/vnd.oasis.opendocument.text",
+		".ods":  "application/vnd.oasis.opendocument.spreadsheet",
+		".odp":  "application/vnd.oasis.opendocument.presentation",
+		".epub": "application/epub+zip",
+		".jar":  "application/java-archive",
+		".apk":  "application/vnd.android.package-archive",
+	},
+	"application/x-ole-storage": {
+		".doc": "application/msword",
+		".xls": "application/vnd.ms-excel",
+		".ppt": "application/vnd.ms-powerpoint",
+		".msi": "application/x-msi",
+	},
+	"text/plain": {
+		".md":   "text/markdown; charset=utf-8",
+		".csv":  "text/csv; charset=utf-8",
+		".json": "application/json",
+		".yaml": "application/yaml",
+		".yml":  "application/yaml",
+	},
+}
+
+// Detect определяет MIME-тип по первым SniffLen байтам содержимого.
+// Расширение filename используется только для уточнения форматов,
+// построенных на zip, OLE или простом тексте
+func Detect(head []byte, filename string) string {
+	if len(head) > SniffLen {
+		head = head[:SniffLen]
+	}
+
+	mimeType := ""
+	for _, sig := range signatures {
+		if bytes.HasPrefix(head, sig.magic) {
+			mimeType = sig.mimeType
+			break
+		}
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(head)
+	}
+
+	if refined, ok := containerTypes[baseType(mimeType)][Extension(filename)]; ok {
+		return refined
+	}
+	return mimeType
+}
+
+// Extension возвращает расширение имени файла в нижнем регистре, с точкой
+func Extension(filename string) string {
+	return strings.ToLower(filepath.Ext(filename))
+}
+
+// SameType сравнивает MIME-типы без учета параметров и регистра
+func SameType(a, b string) bool {
+	return baseType(a) == baseType(b)
+}
+
+// baseType отбрасывает параметры вида "; charset=utf-8"
+func baseType(mimeType string) string {
+	base, _, _ := strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
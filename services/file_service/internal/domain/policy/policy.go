@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Upload - проверяемая загрузка. Пустой MimeType и отрицательный Size
+// означают, что они еще неизвестны и не проверяются
+type Upload struct {
+	Filename string
+	MimeType string
+	Size     int64
+}
+
+// Policy проверяет загрузки по правилам курса
+type Policy struct {
+	defaults Rules
+	courses  map[string]Rules
+}
+
+func New(cfg Config) *Policy {
+	return &Policy{
+		defaults: cfg.Default,
+		courses:  cfg.Courses,
+	}
+}
+
+// Rules возвращает действующие правила курса
+func (p *Policy) Rules(courseID string) Rules {
+	rules := p.defaults
+
+	course, ok := p.courses[courseID]
+	if courseID == "" || !ok {
+		return rules
+	}
+
+	if len(course.AllowedTypes) > 0 || len(course.AllowedExtensions) > 0 {
+		rules.AllowedTypes = course.AllowedTypes
+		rules.AllowedExtensions = course.AllowedExtensions
+	}
+	if course.MaxSize > 0 {
+		rules.MaxSize = course.MaxSize
+	}
+	rules.BlockedTypes = append(slices.Clip(rules.BlockedTypes), course.BlockedTypes...)
+	rules.BlockedExtensions = append(slices.Clip(rules.BlockedExtensions), course.BlockedExtensions...)
+
+	return rules
+}
+
+// MaxSize возвращает ограничение размера файла в курсе, 0 - без ограничения
+func (p *Policy) MaxSize(courseID string) int64 {
+	return p.Rules(courseID).MaxSize
+}
+
+// Check проверяет загрузку в курс courseID. Нарушения возвращаются
+// как InvalidArgument с BadRequest в деталях
+func (p *Policy) Check(courseID string, upload Upload) error {
+	rules := p.Rules(courseID)
+
+	ext := Extension(upload.Filename)
+	mimeType := baseType(upload.MimeType)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field string, format string, args ...any) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if slices.ContainsFunc(rules.BlockedExtensions, sameExtension(ext)) {
+		violate("filename", "extension %s is blocked", ext)
+	}
+	if mimeType != "" && matchType(rules.BlockedTypes, mimeType) {
+		violate("mime_type", "content type %s is blocked", mimeType)
+	}
+
+	// Пока тип неизвестен, отказать можно только по расширению
+	extAllowed := slices.ContainsFunc(rules.AllowedExtensions, sameExtension(ext))
+	switch {
+	case len(rules.AllowedTypes) == 0 && len(rules.AllowedExtensions) == 0:
+	case extAllowed:
+	case mimeType != "" && matchType(rules.AllowedTypes, mimeType):
+	case mimeType == "" && len(rules.AllowedTypes) > 0:
+	case mimeType == "":
+		violate("filename", "extension %q is not allowed, allowed: %s",
+			ext, strings.Join(rules.AllowedExtensions, ", "))
+	default:
+		violate("mime_type", "content type %s is not allowed, allowed: %s",
+			mimeType, strings.Join(append(slices.Clip(rules.AllowedTypes), rules.AllowedExtensions...), ", "))
+	}
+
+	if rules.MaxSize > 0 && upload.Size > rules.MaxSize {
+		violate("size", "file size %d exceeds the limit of %d bytes", upload.Size, rules.MaxSize)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(violations))
+	for i, v := range violations {
+		descriptions[i] = v.Description
+	}
+
+	st := status.New(codes.InvalidArgument, "content policy violation: "+strings.Join(descriptions, "; "))
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// matchType ищет mimeType среди шаблонов вида type/subtype и type/*
+func matchType(patterns []string, mimeType string) bool {
+	for _, pattern := range patterns {
+		pattern = baseType(pattern)
+		if pattern == mimeType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sameExtension сравнивает расширения без учета регистра и точки,
+// в конфиге допускаются и "pdf", и ".pdf"
+func sameExtension(ext string) func(string) bool {
+	ext = strings.TrimPrefix(ext, ".")
+	return func(candidate string) bool {
+		return ext != "" && strings.EqualFold(strings.TrimPrefix(candidate, "."), ext)
+	}
+}
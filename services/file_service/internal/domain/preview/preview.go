@@ -0,0 +1,152 @@
+package preview
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"strings"
+
+	// декодеры форматов, для которых строятся миниатюры
+	_ "image/gif"
+	_ "image/png"
+)
+
+// MimeType - тип всех миниатюр
+const MimeType = "image/jpeg"
+
+const keySuffix = ".preview.jpg"
+
+// ErrUnsupported - для содержимого нельзя построить миниатюру
+var ErrUnsupported = errors.New("preview is not supported")
+
+// Поддерживаются растровые форматы стандартной библиотеки, PDF пока нет
+var supported = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Supported сообщает, можно ли построить миниатюру для типа содержимого
+func Supported(mimeType string) bool {
+	return supported[mimeType]
+}
+
+// Key возвращает ключ миниатюры, она хранится рядом с оригиналом
+func Key(storageKey string) string {
+	return storageKey + keySuffix
+}
+
+// SourceKey возвращает ключ оригинала, если key - ключ миниатюры
+func SourceKey(key string) (string, bool) {
+	return strings.CutSuffix(key, keySuffix)
+}
+
+// Options - ограничения построения миниатюры
+type Options struct {
+	// Size - максимальная сторона миниатюры в пикселях
+	Size int
+	// MaxPixels защищает от изображений, раздувающихся при декодировании
+	MaxPixels int
+	Quality   int
+}
+
+// Thumbnail уменьшает изображение до opts.Size по большей стороне
+// с сохранением пропорций и кодирует в JPEG. Прозрачность заливается белым
+func Thumbnail(src []byte, opts Options) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > opts.MaxPixels {
+		return nil, fmt.Errorf("%w: image is %dx%d", ErrUnsupported, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	width, height := fit(cfg.Width, cfg.Height, opts.Size)
+	thumb := scale(img, width, height)
+
+	// JPEG без альфа-канала, фон под прозрачными пикселями - белый
+	out := image.NewRGBA(thumb.Bounds())
+	draw.Draw(out, out.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), thumb, image.Point{}, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: opts.Quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadSource читает оригинал целиком, отказывая в превышении maxSize
+func ReadSource(reader io.Reader, maxSize int64) ([]byte, error) {
+	src, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(src)) > maxSize {
+		return nil, fmt.Errorf("%w: source exceeds %d bytes", ErrUnsupported, maxSize)
+	}
+	return src, nil
+}
+
+// fit вписывает width x height в квадрат size, не увеличивая изображение
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		return size, max(height*size/width, 1)
+	}
+	return max(width*size/height, 1), size
+}
+
+// scale уменьшает изображение усреднением пикселей по области
+func scale(img image.Image, width, height int) *image.RGBA {
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := max((y+1)*sh/height, y0+1)
+
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := max((x+1)*sw/width, x0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				off := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[off])
+					g += uint64(src.Pix[off+1])
+					bl += uint64(src.Pix[off+2])
+					a += uint64(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			d := y*dst.Stride + x*4
+			dst.Pix[d] = uint8(r / n)
+			dst.Pix[d+1] = uint8(g / n)
+			dst.Pix[d+2] = uint8(bl / n)
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,134 @@
+package clamd
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan"
+)
+
+// Scanner передает содержимое демону clamd командой INSTREAM:
+// чанки с 4-байтной длиной в network byte order и нулевой чанк в конце.
+// На каждую проверку открывается отдельное соединение
+type Scanner struct {
+	network   string
+	address   string
+	timeout   time.Duration
+	chunkSize int
+}
+
+func New(cfg Config) *Scanner {
+	return &Scanner{
+		network:   cfg.Network,
+		address:   cfg.Address,
+		timeout:   cfg.Timeout,
+		chunkSize: max(cfg.ChunkSize, 1),
+	}
+}
+
+func (s *Scanner) Scan(ctx context.Context, content io.Reader) (scan.Verdict, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return scan.Verdict{}, fmt.Errorf("clamd dial failed: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return scan.Verdict{}, err
+	}
+
+	// Отмена ctx прерывает ожидание на сокете
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := s.stream(conn, content); err != nil {
+		// clamd закрывает соединение при превышении лимита,
+		// причина приходит ответом
+		if reply, replyErr := readReply(conn); replyErr == nil && reply != "" {
+			return parseReply(reply)
+		}
+		return scan.Verdict{}, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return scan.Verdict{}, fmt.Errorf("clamd reply read failed: %w", err)
+	}
+
+	return parseReply(reply)
+}
+
+func (s *Scanner) stream(conn net.Conn, content io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("clamd write failed: %w", err)
+	}
+
+	buf := make([]byte, 4+s.chunkSize)
+	for {
+		n, err := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return fmt.Errorf("clamd write failed: %w", err)
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("content read failed: %w", err)
+		}
+	}
+
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("clamd write failed: %w", err)
+	}
+	return nil
+}
+
+// readReply читает ответ до завершающего нуля команды с префиксом z
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// parseReply разбирает "stream: OK", "stream: <сигнатура> FOUND"
+// и "<причина> ERROR"
+func parseReply(reply string) (scan.Verdict, error) {
+	_, result, ok := strings.Cut(reply, "stream: ")
+	if !ok {
+		result = reply
+	}
+
+	switch {
+	case result == "OK":
+		return scan.Verdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return scan.Verdict{
+			Infected:  true,
+			Signature: strings.TrimSuffix(result, " FOUND"),
+		}, nil
+	case strings.HasSuffix(result, " ERROR"):
+		return scan.Verdict{}, fmt.Errorf("%w: clamd: %s", scan.ErrUnscannable, strings.TrimSuffix(result, " ERROR"))
+	}
+
+	return scan.Verdict{}, fmt.Errorf("unexpected clamd reply %q", reply)
+}
@@ -0,0 +1,226 @@
+package clamd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan"
+)
+
+// fakeClamd принимает одно соединение INSTREAM и отвечает reply на
+// полученное содержимое. При limit > 0 ведет себя как clamd с
+// StreamMaxLength: отвечает ошибкой, как только содержимое его превысит
+type fakeClamd struct {
+	listener net.Listener
+	limit    int
+	reply    func(content []byte) string
+
+	chunks  []int
+	content []byte
+	err     error
+	done    chan struct{}
+}
+
+func startClamd(t *testing.T, limit int, reply func(content []byte) string) *fakeClamd {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	c := &fakeClamd{listener: listener, limit: limit, reply: reply, done: make(chan struct{})}
+	go c.serve()
+	return c
+}
+
+func (c *fakeClamd) serve() {
+	defer close(c.done)
+
+	conn, err := c.listener.Accept()
+	if err != nil {
+		c.err = err
+		return
+	}
+	defer conn.Close()
+
+	c.err = c.handle(conn)
+}
+
+func (c *fakeClamd) handle(conn net.Conn) error {
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+
+	command := make([]byte, len("zINSTREAM\x00"))
+	if _, err := io.ReadFull(conn, command); err != nil {
+		return err
+	}
+	if string(command) != "zINSTREAM\x00" {
+		return errors.New("unexpected command " + string(command))
+	}
+
+	for {
+		var size uint32
+		if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		if size == 0 {
+			break
+		}
+
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(conn, chunk); err != nil {
+			return err
+		}
+		c.chunks = append(c.chunks, int(size))
+		c.content = append(c.content, chunk...)
+
+		if c.limit > 0 && len(c.content) > c.limit {
+			_, err := conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+			// Остаток потока вычитывается, чтобы закрытие сокета
+			// с непрочитанными данными не сбросило соединение до ответа
+			_, _ = io.Copy(io.Discard, conn)
+			return err
+		}
+	}
+
+	_, err := conn.Write([]byte(c.reply(c.content) + "\x00"))
+	return err
+}
+
+func (c *fakeClamd) wait(t *testing.T) {
+	t.Helper()
+
+	<-c.done
+	if c.err != nil {
+		t.Fatalf("fake clamd: %v", c.err)
+	}
+}
+
+func newScanner(c *fakeClamd, chunkSize int) *Scanner {
+	return New(Config{
+		Network:   "tcp",
+		Address:   c.listener.Addr().String(),
+		Timeout:   5 * time.Second,
+		ChunkSize: chunkSize,
+	})
+}
+
+func TestScanChunks(t *testing.T) {
+	c := startClamd(t, 0, func([]byte) string { return "stream: OK" })
+
+	content := []byte("0123456789")
+	verdict, err := newScanner(c, 4).Scan(context.Background(), bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if verdict.Infected {
+		t.Errorf("verdict = %+v, want clean", verdict)
+	}
+
+	c.wait(t)
+	if want := []int{4, 4, 2}; !slices.Equal(c.chunks, want) {
+		t.Errorf("chunks = %v, want %v", c.chunks, want)
+	}
+	if !bytes.Equal(c.content, content) {
+		t.Errorf("clamd got %q, want %q", c.content, content)
+	}
+}
+
+func TestScanEmpty(t *testing.T) {
+	c := startClamd(t, 0, func([]byte) string { return "stream: OK" })
+
+	if _, err := newScanner(c, 4).Scan(context.Background(), bytes.NewReader(nil)); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	c.wait(t)
+	if len(c.chunks) != 0 {
+		t.Errorf("chunks = %v, want none", c.chunks)
+	}
+}
+
+func TestScanFound(t *testing.T) {
+	c := startClamd(t, 0, func(content []byte) string {
+		if bytes.Contains(content, []byte("virus")) {
+			return "stream: Win.Test.Virus FOUND"
+		}
+		return "stream: OK"
+	})
+
+	verdict, err := newScanner(c, 3).Scan(context.Background(), strings.NewReader("some virus inside"))
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if !verdict.Infected || verdict.Signature != "Win.Test.Virus" {
+		t.Errorf("verdict = %+v, want Win.Test.Virus", verdict)
+	}
+
+	c.wait(t)
+}
+
+func TestScanSizeLimit(t *testing.T) {
+	c := startClamd(t, 1024, func([]byte) string { return "stream: OK" })
+
+	content := bytes.Repeat([]byte("x"), 1<<20)
+	_, err := newScanner(c, 256).Scan(context.Background(), bytes.NewReader(content))
+	if !errors.Is(err, scan.ErrUnscannable) {
+		t.Fatalf("scan = %v, want ErrUnscannable", err)
+	}
+	if !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Errorf("error %q has no clamd reason", err)
+	}
+
+	c.wait(t)
+}
+
+func TestScanUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	s := New(Config{Network: "tcp", Address: address, Timeout: time.Second, ChunkSize: 4})
+
+	_, err = s.Scan(context.Background(), strings.NewReader("content"))
+	if err == nil || errors.Is(err, scan.ErrUnscannable) {
+		t.Fatalf("scan = %v, want a temporary error", err)
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		reply       string
+		verdict     scan.Verdict
+		unscannable bool
+		fails       bool
+	}{
+		{reply: "stream: OK"},
+		{reply: "stream: Eicar-Signature FOUND", verdict: scan.Verdict{Infected: true, Signature: "Eicar-Signature"}},
+		{reply: "INSTREAM size limit exceeded. ERROR", unscannable: true, fails: true},
+		{reply: "stream: Can't allocate memory ERROR", unscannable: true, fails: true},
+		{reply: "UNKNOWN COMMAND", fails: true},
+	}
+
+	for _, tt := range tests {
+		verdict, err := parseReply(tt.reply)
+		if (err != nil) != tt.fails || errors.Is(err, scan.ErrUnscannable) != tt.unscannable {
+			t.Errorf("parseReply(%q) error = %v", tt.reply, err)
+		}
+		if verdict != tt.verdict {
+			t.Errorf("parseReply(%q) = %+v, want %+v", tt.reply, verdict, tt.verdict)
+		}
+	}
+}
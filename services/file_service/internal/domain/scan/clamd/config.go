@@ -0,0 +1,13 @@
+package clamd
+
+import "time"
+
+type Config struct {
+	// Network - tcp или unix
+	Network string        `yaml:"network" env-default:"tcp"`
+	Address string        `yaml:"address" env-default:"localhost:3310"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5m"`
+
+	// Размер чанка INSTREAM, не больше StreamMaxLength в clamd.conf
+	ChunkSize int `yaml:"chunk_size" env-default:"65536"`
+}
@@ -0,0 +1,54 @@
+package eicar
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan"
+)
+
+// Signature - тестовая строка EICAR, которую антивирусы обязаны
+// распознавать как вредоносную
+const Signature = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// SignatureName - имя сигнатуры в вердикте, как у clamd
+const SignatureName = "Eicar-Signature"
+
+// Scanner находит строку EICAR в любом месте содержимого. Предназначен
+// для тестов и стендов без настоящего антивируса
+type Scanner struct{}
+
+func New() *Scanner {
+	return &Scanner{}
+}
+
+func (s *Scanner) Scan(ctx context.Context, content io.Reader) (scan.Verdict, error) {
+	signature := []byte(Signature)
+
+	// Хвост предыдущего чанка сохраняется, чтобы найти строку на стыке
+	buf := make([]byte, 0, 64<<10)
+	chunk := make([]byte, 32<<10)
+	for {
+		if err := ctx.Err(); err != nil {
+			return scan.Verdict{}, err
+		}
+
+		n, err := content.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+
+		if bytes.Contains(buf, signature) {
+			return scan.Verdict{Infected: true, Signature: SignatureName}, nil
+		}
+		if tail := len(signature) - 1; len(buf) > tail {
+			buf = append(buf[:0], buf[len(buf)-tail:]...)
+		}
+
+		if err == io.EOF {
+			return scan.Verdict{}, nil
+		}
+		if err != nil {
+			return scan.Verdict{}, err
+		}
+	}
+}
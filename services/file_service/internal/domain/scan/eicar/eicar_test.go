@@ -0,0 +1,70 @@
+package eicar
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScan(t *testing.T) {
+	padding := strings.Repeat("x", 32<<10-10)
+
+	tests := []struct {
+		name     string
+		content  string
+		infected bool
+	}{
+		{name: "empty", content: ""},
+		{name: "clean", content: strings.Repeat("clean content ", 10000)},
+		{name: "partial signature", content: Signature[:len(Signature)-1]},
+		{name: "signature only", content: Signature, infected: true},
+		{name: "signature inside", content: "prefix " + Signature + " suffix", infected: true},
+		// Строка приходится на стык двух чтений
+		{name: "signature across reads", content: padding + Signature + padding, infected: true},
+	}
+
+	readers := map[string]func(string) io.Reader{
+		"whole":     func(s string) io.Reader { return strings.NewReader(s) },
+		"one byte":  func(s string) io.Reader { return iotest.OneByteReader(strings.NewReader(s)) },
+		"half read": func(s string) io.Reader { return iotest.HalfReader(strings.NewReader(s)) },
+	}
+
+	for _, tt := range tests {
+		for mode, reader := range readers {
+			t.Run(tt.name+"/"+mode, func(t *testing.T) {
+				verdict, err := New().Scan(context.Background(), reader(tt.content))
+				if err != nil {
+					t.Fatalf("scan: %v", err)
+				}
+				if verdict.Infected != tt.infected {
+					t.Errorf("infected = %v, want %v", verdict.Infected, tt.infected)
+				}
+				if tt.infected && verdict.Signature != SignatureName {
+					t.Errorf("signature = %q, want %q", verdict.Signature, SignatureName)
+				}
+			})
+		}
+	}
+}
+
+func TestScanReadError(t *testing.T) {
+	failure := errors.New("read failed")
+
+	_, err := New().Scan(context.Background(), iotest.ErrReader(failure))
+	if !errors.Is(err, failure) {
+		t.Fatalf("scan = %v, want %v", err, failure)
+	}
+}
+
+func TestScanCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New().Scan(ctx, strings.NewReader(Signature))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("scan = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,31 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrUnscannable - сканер отказался проверять содержимое, например из-за
+// превышения лимита размера. Повторная проверка не поможет
+var ErrUnscannable = errors.New("content cannot be scanned")
+
+// Verdict - результат проверки
+type Verdict struct {
+	Infected bool
+	// Signature - имя найденной сигнатуры, задано для зараженного содержимого
+	Signature string
+}
+
+// Scanner проверяет содержимое на вредоносное ПО. Ошибки, кроме
+// ErrUnscannable, считаются временными (сканер недоступен)
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) (Verdict, error)
+}
+
+// Noop признает чистым любое содержимое, для окружений без антивируса
+type Noop struct{}
+
+func (Noop) Scan(context.Context, io.Reader) (Verdict, error) {
+	return Verdict{}, nil
+}
@@ -0,0 +1,68 @@
+package textract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ограничение на распакованный document.xml, защита от zip-бомб
+const maxDocumentXML = 64 << 20
+
+// docxText собирает текст из word/document.xml: содержимое w:t,
+// абзацы и разрывы строк переводятся в переводы строк
+func docxText(src []byte, maxSize int) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(src), int64(len(src)))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	document, err := archive.Open("word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	defer document.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(document, maxDocumentXML))
+
+	var text strings.Builder
+	inText := false
+	for text.Len() < maxSize {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		}
+	}
+
+	return text.String(), nil
+}
@@ -0,0 +1,295 @@
+package textract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Ограничение на распакованный поток, защита от zip-бомб
+const maxPDFStream = 16 << 20
+
+// pdfText вытаскивает строки текстовых операторов (Tj, TJ, ', ")
+// из несжатых и FlateDecode потоков. Строки декодируются как Latin-1
+// или UTF-16BE с BOM; текст во шрифтах с CID-кодировкой и в
+// отсканированных страницах не извлекается
+func pdfText(src []byte, maxSize int) (string, error) {
+	if !bytes.HasPrefix(src, []byte("%PDF-")) {
+		return "", fmt.Errorf("%w: not a pdf", ErrUnsupported)
+	}
+
+	var text strings.Builder
+	rest := src
+	for text.Len() < maxSize {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+
+		dict := rest[:start]
+		if i := bytes.LastIndex(dict, []byte(" obj")); i >= 0 {
+			dict = dict[i:]
+		}
+
+		body := rest[start+len("stream"):]
+		body = bytes.TrimPrefix(body, []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		rest = body[end+len("endstream"):]
+
+		content, ok := decodeStream(dict, body[:end])
+		if ok {
+			contentText(&text, content)
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("%w: no extractable text", ErrUnsupported)
+	}
+	return text.String(), nil
+}
+
+// decodeStream распаковывает поток, если он не сжат или сжат FlateDecode
+func decodeStream(dict []byte, data []byte) ([]byte, bool) {
+	if !bytes.Contains(dict, []byte("/Filter")) {
+		return data, true
+	}
+
+	filter := dict[bytes.Index(dict, []byte("/Filter")):]
+	if !bytes.HasPrefix(bytes.TrimLeft(filter[len("/Filter"):], " []\r\n"), []byte("/FlateDecode")) {
+		return nil, false
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+
+	// Поврежденный хвост потока не мешает использовать прочитанное
+	content, _ := io.ReadAll(io.LimitReader(reader, maxPDFStream))
+	return content, len(content) > 0
+}
+
+// contentText разбирает операторы потока содержимого страницы
+// и дописывает строки внутри BT ... ET
+func contentText(text *strings.Builder, content []byte) {
+	var operands []string
+	inText := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+
+		switch {
+		case c == '(':
+			s, next := literalString(content, i)
+			operands = append(operands, s)
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] != '<':
+			s, next := hexString(content, i)
+			operands = append(operands, s)
+			i = next
+		case c == '[' || c == ']':
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isDelimiter(c):
+			i++
+		default:
+			start := i
+			for i < len(content) && !isDelimiter(content[i]) && content[i] != '(' &&
+				content[i] != '<' && content[i] != '[' && content[i] != ']' {
+				i++
+			}
+			if i == start {
+				i++
+				continue
+			}
+
+			token := string(content[start:i])
+			if isNumber(token) {
+				// Большой отрицательный сдвиг в TJ обычно означает пробел
+				if len(token) > 1 && token[0] == '-' && numberAbove(token[1:], 200) {
+					operands = append(operands, " ")
+				}
+				continue
+			}
+
+			switch token {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				text.WriteByte('\n')
+			case "Tj", "TJ", "'", "\"":
+				if inText {
+					if token != "Tj" && token != "TJ" {
+						text.WriteByte('\n')
+					}
+					for _, s := range operands {
+						text.WriteString(s)
+					}
+				}
+			case "T*", "Td", "TD":
+				if inText {
+					text.WriteByte(' ')
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+}
+
+// literalString читает строку (...) с вложенными скобками и escape-последовательностями
+func literalString(content []byte, i int) (string, int) {
+	var out []byte
+	depth := 0
+
+	for i++; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '\\':
+			i++
+			if i >= len(content) {
+				break
+			}
+			switch e := content[i]; e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// перенос строки внутри строки игнорируется
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					for n := 0; n < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; n++ {
+						v = v*8 + int(content[i]-'0')
+						i++
+					}
+					i--
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			if depth == 0 {
+				return decodeString(out), i + 1
+			}
+			depth--
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return decodeString(out), len(content)
+}
+
+// hexString читает строку <...>
+func hexString(content []byte, i int) (string, int) {
+	end := bytes.IndexByte(content[i:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+
+	var digits []byte
+	for _, c := range content[i+1 : i+end] {
+		if hexValue(c) >= 0 {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, len(digits)/2)
+	for n := range out {
+		out[n] = byte(hexValue(digits[2*n])<<4 | hexValue(digits[2*n+1]))
+	}
+
+	return decodeString(out), i + end + 1
+}
+
+// decodeString переводит байты строки в текст: UTF-16BE при наличии BOM,
+// иначе Latin-1. Управляющие символы отбрасываются
+func decodeString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xfe && b[1] == 0xff {
+		units := make([]uint16, 0, len(b)/2)
+		for n := 2; n+1 < len(b); n += 2 {
+			units = append(units, uint16(b[n])<<8|uint16(b[n+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runes := make([]rune, 0, len(b))
+	for _, c := range b {
+		if c >= 0x20 || c == '\n' || c == '\t' {
+			runes = append(runes, rune(c))
+		}
+	}
+	return string(runes)
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return -1
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '/', '{', '}', ')', '>':
+		return true
+	}
+	return false
+}
+
+func isNumber(token string) bool {
+	token = strings.TrimLeft(token, "+-")
+	if token == "" {
+		return false
+	}
+	for _, c := range token {
+		if (c < '0' || c > '9') && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// numberAbove сравнивает неотрицательное число из токена с limit
+func numberAbove(token string, limit int) bool {
+	whole, _, _ := strings.Cut(token, ".")
+	if len(whole) > 9 {
+		return true
+	}
+
+	v := 0
+	for _, c := range whole {
+		v = v*10 + int(c-'0')
+	}
+	return v > limit
+}
@@ -0,0 +1,66 @@
+package textract
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnsupported - из содержимого нельзя извлечь текст
+var ErrUnsupported = errors.New("text extraction is not supported")
+
+const (
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimePDF  = "application/pdf"
+)
+
+// Supported сообщает, умеет ли Extract работать с типом содержимого
+func Supported(mimeType string) bool {
+	switch baseType(mimeType) {
+	case "text/plain", "text/markdown", mimeDOCX, mimePDF:
+		return true
+	}
+	return false
+}
+
+// Extract извлекает текст документа для поиска, обрезая его до maxSize байт
+func Extract(src []byte, mimeType string, maxSize int) (string, error) {
+	var text string
+	var err error
+
+	switch baseType(mimeType) {
+	case "text/plain", "text/markdown":
+		text = string(src)
+	case mimeDOCX:
+		text, err = docxText(src, maxSize)
+	case mimePDF:
+		text, err = pdfText(src, maxSize)
+	default:
+		return "", ErrUnsupported
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return clean(text, maxSize), nil
+}
+
+// clean делает текст пригодным для Postgres: валидный UTF-8 без NUL,
+// обрезанный по границе символа
+func clean(text string, maxSize int) string {
+	text = strings.ToValidUTF8(text, " ")
+	text = strings.ReplaceAll(text, "\x00", " ")
+
+	if len(text) > maxSize {
+		text = text[:maxSize]
+		for len(text) > 0 && !utf8.ValidString(text) {
+			text = text[:len(text)-1]
+		}
+	}
+	return text
+}
+
+func baseType(mimeType string) string {
+	base, _, _ := strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
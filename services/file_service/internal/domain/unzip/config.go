@@ -0,0 +1,16 @@
+package unzip
+
+// Config - ограничения распаковки загруженных архивов
+type Config struct {
+	// Размер самого архива
+	MaxArchiveSize int64 `yaml:"max_archive_size" env-default:"1073741824"`
+
+	// Число записей, включая каталоги
+	MaxEntries int `yaml:"max_entries" env-default:"1000"`
+
+	// Суммарный размер распакованного содержимого
+	MaxSize int64 `yaml:"max_size" env-default:"4294967296"`
+
+	// Во сколько раз запись может сжиматься, больше - признак zip-бомбы
+	MaxRatio int64 `yaml:"max_ratio" env-default:"100"`
+}
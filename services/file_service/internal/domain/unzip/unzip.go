@@ -0,0 +1,183 @@
+package unzip
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"unicode/utf8"
+)
+
+// Записи меньше порога не проверяются на степень сжатия:
+// небольшие текстовые файлы законно сжимаются в сотни раз
+const ratioThreshold = 1 << 20
+
+var (
+	ErrTooManyEntries = errors.New("too many entries in archive")
+	ErrTooLarge       = errors.New("archive unpacks to too much data")
+
+	ErrUnsafePath = errors.New("entry path points outside of the archive")
+	ErrDuplicate  = errors.New("duplicate entry path")
+	ErrEncrypted  = errors.New("encrypted entries are not supported")
+	ErrSymlink    = errors.New("symbolic links are not supported")
+	ErrRatio      = errors.New("entry compression ratio is too high")
+)
+
+// Entry - запись архива с проверенным путем
+type Entry struct {
+	Path string   // нормализованный путь через "/"
+	Dirs []string // каталоги записи от корня архива
+	Name string   // имя файла, пусто у каталога
+	Size int64    // заявленный размер после распаковки
+
+	// Err - почему запись нельзя распаковать, остальные записи
+	// это не затрагивает
+	Err error
+
+	file *zip.File
+}
+
+func (e *Entry) IsDir() bool {
+	return e.Name == ""
+}
+
+// Open открывает содержимое записи. archive/zip сам не дает прочитать
+// больше заявленного размера и сверяет CRC-32 в конце
+func (e *Entry) Open() (io.ReadCloser, error) {
+	return e.file.Open()
+}
+
+// Entries проверяет архив целиком - число записей и суммарный размер
+// после распаковки - и возвращает записи в порядке архива. Служебные
+// файлы macOS пропускаются
+func Entries(r *zip.Reader, cfg Config) ([]*Entry, error) {
+	if cfg.MaxEntries > 0 && len(r.File) > cfg.MaxEntries {
+		return nil, fmt.Errorf("%w: %d, at most %d allowed", ErrTooManyEntries, len(r.File), cfg.MaxEntries)
+	}
+
+	entries := make([]*Entry, 0, len(r.File))
+	seen := make(map[string]bool, len(r.File))
+
+	var total uint64
+	for _, f := range r.File {
+		entry := newEntry(f, cfg)
+		if entry == nil {
+			continue
+		}
+
+		if entry.Err == nil && !entry.IsDir() {
+			if seen[entry.Path] {
+				entry.Err = ErrDuplicate
+			}
+			seen[entry.Path] = true
+
+			total += f.UncompressedSize64
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if cfg.MaxSize > 0 && total > uint64(cfg.MaxSize) {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d allowed", ErrTooLarge, total, cfg.MaxSize)
+	}
+
+	return entries, nil
+}
+
+func newEntry(f *zip.File, cfg Config) *Entry {
+	name := f.Name
+	if f.NonUTF8 && !utf8.ValidString(name) {
+		name = decodeCP866(name)
+	}
+
+	parts, err := splitPath(name)
+	entry := &Entry{Path: strings.Join(parts, "/"), Err: err, file: f}
+	if err != nil {
+		entry.Path = name
+		return entry
+	}
+
+	if isJunk(parts) {
+		return nil
+	}
+
+	mode := f.Mode()
+	if mode.IsDir() || strings.HasSuffix(name, "/") {
+		entry.Dirs = parts
+		return entry
+	}
+
+	entry.Dirs = parts[:len(parts)-1]
+	entry.Name = parts[len(parts)-1]
+	entry.Size = int64(f.UncompressedSize64)
+
+	switch {
+	case mode&fs.ModeSymlink != 0:
+		entry.Err = ErrSymlink
+	case f.Flags&0x1 != 0:
+		entry.Err = ErrEncrypted
+	case cfg.MaxRatio > 0 && f.UncompressedSize64 > ratioThreshold &&
+		f.UncompressedSize64 > f.CompressedSize64*uint64(cfg.MaxRatio):
+		entry.Err = ErrRatio
+	}
+
+	return entry
+}
+
+// splitPath разбивает путь записи на части. Абсолютные пути, буквы
+// дисков и ".." отклоняются целиком, а не обрезаются: такая запись
+// почти наверняка собрана, чтобы выйти за пределы распаковки (zip-slip)
+func splitPath(name string) ([]string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if strings.HasPrefix(name, "/") || (len(name) >= 2 && name[1] == ':') {
+		return nil, ErrUnsafePath
+	}
+
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			return nil, ErrUnsafePath
+		}
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		return nil, ErrUnsafePath
+	}
+	return parts, nil
+}
+
+// isJunk отсеивает метаданные, которые добавляет архиватор macOS
+func isJunk(parts []string) bool {
+	return parts[0] == "__MACOSX" || parts[len(parts)-1] == ".DS_Store"
+}
+
+// decodeCP866 переводит имя из OEM-кодировки, в которой архиватор
+// Windows сохраняет кириллицу. Символы псевдографики заменяются на '_'
+func decodeCP866(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c < 0x80:
+			b.WriteByte(c)
+		case c <= 0xAF:
+			b.WriteRune('А' + rune(c-0x80)) // А-Я, а-п
+		case c >= 0xE0 && c <= 0xEF:
+			b.WriteRune('р' + rune(c-0xE0))
+		case c == 0xF0:
+			b.WriteRune('Ё')
+		case c == 0xF1:
+			b.WriteRune('ё')
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "mime"
+
+// ContentDisposition формирует заголовок Content-Disposition,
+// имя файла кодируется по RFC 2231 при необходимости
+func ContentDisposition(filename string, inline bool) string {
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+
+	if v := mime.FormatMediaType(disposition, map[string]string{"filename": filename}); v != "" {
+		return v
+	}
+	return disposition
+}
@@ -5,20 +5,36 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-func UserIDFromContext(ctx context.Context) (uuid.UUID, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
+type callerKey struct{}
+
+// Caller - пользователь, чей JWT проверил middleware
+type Caller struct {
+	ID    uuid.UUID
+	Role  string
+	Token string
+}
+
+func ContextWithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func CallerFromContext(ctx context.Context) (Caller, error) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
 	if !ok {
-		return uuid.Nil, status.Error(codes.Unauthenticated, "missing metadata")
+		return Caller{}, status.Error(codes.Unauthenticated, "missing user id")
 	}
 
-	values := md.Get("x-user-id")
-	if len(values) == 0 {
-		return uuid.Nil, status.Error(codes.Unauthenticated, "missing user id")
+	return caller, nil
+}
+
+func UserIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	caller, err := CallerFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
-	return uuid.Parse(values[0])
+	return caller.ID, nil
 }
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	tokenSize      = 32
+	saltSize       = 16
+	passwordKeyLen = 32
+	passwordIter   = 600_000
+)
+
+// NewToken возвращает случайный токен для использования в URL
+func NewToken() string {
+	b := make([]byte, tokenSize)
+	// rand.Read не возвращает ошибок начиная с go 1.24
+	_, _ = rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// HashToken возвращает SHA-256 токена в hex. Токен случаен и длинен,
+// поэтому соль и медленный хеш ему не нужны
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashPassword возвращает "соль$хеш" PBKDF2-SHA256 в hex
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, saltSize)
+	_, _ = rand.Read(salt)
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIter, passwordKeyLen)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(salt) + "$" + hex.EncodeToString(key), nil
+}
+
+// CheckPassword сравнивает пароль с результатом HashPassword
+func CheckPassword(hash string, password string) bool {
+	rawSalt, rawKey, ok := strings.Cut(hash, "$")
+	if !ok {
+		return false
+	}
+
+	salt, err := hex.DecodeString(rawSalt)
+	if err != nil {
+		return false
+	}
+	want, err := hex.DecodeString(rawKey)
+	if err != nil {
+		return false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIter, len(want))
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(key, want) == 1
+}
@@ -0,0 +1,186 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const archivePattern = "/files/archive"
+
+// downloadArchive отдает ZIP по мере того, как его собирает сервер.
+// Файлы задаются ?file_ids= (повтором параметра или через запятую),
+// ?folder_id= либо ?course_id= и ?group_id=, имя архива - ?name=
+func (g *Gateway) downloadArchive(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_DownloadArchive_FullMethodName,
+		runtime.WithHTTPPathPattern(archivePattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+
+	req := &pb.DownloadArchiveRequest{
+		CourseId: query.Get("course_id"),
+		GroupId:  query.Get("group_id"),
+		FolderId: query.Get("folder_id"),
+		Name:     query.Get("name"),
+	}
+	for _, ids := range query["file_ids"] {
+		for _, id := range strings.Split(ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				req.FileIds = append(req.FileIds, id)
+			}
+		}
+	}
+
+	stream, err := g.client.DownloadArchive(ctx, req)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	msg, err := stream.Recv()
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	header := msg.GetHeader()
+	if header == nil {
+		g.httpError(w, r, status.Error(codes.Internal, "archive stream has no header"))
+		return
+	}
+
+	// Размер архива заранее неизвестен, ответ уходит chunked
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", utils.ContentDisposition(header.Name, false))
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// заголовки уже отправлены, остается только оборвать ответ
+			g.logger.Warnf("archive download interrupted: %s", err)
+			return
+		}
+
+		if _, err := w.Write(msg.GetChunk()); err != nil {
+			return
+		}
+	}
+}
+
+const uploadArchivePattern = "/files/upload/archive"
+
+// uploadArchive принимает multipart/form-data: поля course_id, group_id,
+// folder_id и часть file с ZIP-архивом, которая должна идти последней
+func (g *Gateway) uploadArchive(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_UploadArchive_FullMethodName,
+		runtime.WithHTTPPathPattern(uploadArchivePattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		g.httpError(w, r, status.Errorf(codes.InvalidArgument, "expected multipart/form-data: %v", err))
+		return
+	}
+
+	meta := &pb.UploadArchiveMetadata{}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			g.httpError(w, r, status.Error(codes.InvalidArgument, "file part is missing"))
+			return
+		}
+		if err != nil {
+			g.httpError(w, r, status.Errorf(codes.InvalidArgument, "malformed multipart body: %v", err))
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFieldSize))
+			if err != nil {
+				g.httpError(w, r, status.Errorf(codes.InvalidArgument, "failed to read field %s: %v", part.FormName(), err))
+				return
+			}
+
+			switch part.FormName() {
+			case "course_id":
+				meta.CourseId = string(value)
+			case "group_id":
+				meta.GroupId = string(value)
+			case "folder_id":
+				meta.FolderId = string(value)
+			}
+			continue
+		}
+
+		resp, err := g.streamArchive(ctx, meta, part)
+		if err != nil {
+			g.httpError(w, r, err)
+			return
+		}
+
+		_, outbound := runtime.MarshalerForRequest(g.mux, r)
+		runtime.ForwardResponseMessage(ctx, g.mux, outbound, w, r, resp)
+		return
+	}
+}
+
+func (g *Gateway) streamArchive(
+	ctx context.Context,
+	meta *pb.UploadArchiveMetadata,
+	body io.Reader,
+) (*pb.UploadArchiveResponse, error) {
+
+	stream, err := g.client.UploadArchive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&pb.UploadArchiveRequest{
+		Data: &pb.UploadArchiveRequest_Metadata{Metadata: meta},
+	})
+	if err != nil {
+		// настоящая причина придет в CloseAndRecv
+		_, err = stream.CloseAndRecv()
+		return nil, err
+	}
+
+	err = sendBody(body, func(chunk []byte) error {
+		return stream.Send(&pb.UploadArchiveRequest{
+			Data: &pb.UploadArchiveRequest_Chunk{Chunk: chunk},
+		})
+	})
+	if err != nil && !errors.Is(err, errStreamClosed) {
+		return nil, err
+	}
+
+	return stream.CloseAndRecv()
+}
@@ -0,0 +1,316 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const downloadPattern = "/files/download/{file_id}"
+
+// byteRange - разобранный заголовок Range с одним диапазоном
+type byteRange struct {
+	start  int64
+	end    int64 // -1 - до конца файла
+	suffix int64 // > 0 - последние suffix байт (bytes=-N)
+}
+
+// downloadStream - общий вид стримов DownloadFile, DownloadFileVersion
+// и DownloadShared
+type downloadStream interface {
+	Recv() (*pb.DownloadFileResponse, error)
+}
+
+// downloadSource открывает стрим скачивания и узнает размер содержимого
+// для Range вида bytes=-N и ответа 416
+type downloadSource struct {
+	name string // для логов
+	open func(ctx context.Context, offset, length int64) (downloadStream, error)
+	size func(ctx context.Context) (int64, error)
+}
+
+// download отдает файл как есть, поддерживая Range, If-None-Match
+// и Content-Disposition (?inline=true для просмотра в браузере).
+// ?version=N отдает указанную версию вместо текущей
+func (g *Gateway) download(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_DownloadFile_FullMethodName,
+		runtime.WithHTTPPathPattern(downloadPattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	fileID := pathParams["file_id"]
+
+	var version int32
+	if v := r.URL.Query().Get("version"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n <= 0 {
+			g.httpError(w, r, status.Error(codes.InvalidArgument, "version must be a positive integer"))
+			return
+		}
+		version = int32(n)
+	}
+
+	g.serveDownload(ctx, w, r, downloadSource{
+		name: fileID,
+		open: func(ctx context.Context, offset, length int64) (downloadStream, error) {
+			if version > 0 {
+				return g.client.DownloadFileVersion(ctx, &pb.DownloadFileVersionRequest{
+					FileId:  fileID,
+					Version: version,
+					Offset:  offset,
+					Length:  length,
+				})
+			}
+			return g.client.DownloadFile(ctx, &pb.DownloadFileRequest{
+				FileId: fileID,
+				Offset: offset,
+				Length: length,
+			})
+		},
+		size: func(ctx context.Context) (int64, error) {
+			return g.size(ctx, fileID, version)
+		},
+	})
+}
+
+// serveDownload отдает содержимое из src с учетом Range и If-None-Match
+func (g *Gateway) serveDownload(ctx context.Context, w http.ResponseWriter, r *http.Request, src downloadSource) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var offset, length int64
+
+	// Некорректный или составной Range игнорируется, отдаем файл целиком
+	rng, hasRange := parseRange(r.Header.Get("Range"))
+	if hasRange {
+		if rng.suffix > 0 {
+			size, err := src.size(ctx)
+			if err != nil {
+				g.httpError(w, r, err)
+				return
+			}
+			rng.start = max(size-rng.suffix, 0)
+		}
+
+		offset = rng.start
+		if rng.end >= 0 {
+			length = rng.end - rng.start + 1
+		}
+	}
+
+	stream, err := src.open(ctx, offset, length)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	msg, err := stream.Recv()
+	if status.Code(err) == codes.OutOfRange && hasRange {
+		g.rangeNotSatisfiable(ctx, w, r, src)
+		return
+	}
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	header := msg.GetHeader()
+	if header == nil {
+		g.httpError(w, r, status.Error(codes.Internal, "download stream has no header"))
+		return
+	}
+	file := header.File
+
+	etag := strconv.Quote(strings.Trim(header.Etag, `"`))
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", etag)
+	// Пользовательское содержимое не должно исполняться в origin
+	// сервиса, даже если браузер откроет его как страницу
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if hasRange && header.Length == 0 {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", file.Size))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	inline := r.URL.Query().Get("inline") == "true" && !activeContent(contentType)
+	w.Header().Set("Content-Disposition", utils.ContentDisposition(file.Name, inline))
+	w.Header().Set("Content-Length", strconv.FormatInt(header.Length, 10))
+	setDigest(w.Header(), file)
+
+	if hasRange {
+		w.Header().Set("Content-Range", fmt.Sprintf(
+			"bytes %d-%d/%d",
+			header.Offset,
+			header.Offset+header.Length-1,
+			file.Size,
+		))
+		w.WriteHeader(http.StatusPartialContent)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// заголовки уже отправлены, остается только оборвать ответ
+			g.logger.Warnf("download of %s interrupted: %s", src.name, err)
+			return
+		}
+
+		if _, err := w.Write(msg.GetChunk()); err != nil {
+			return
+		}
+	}
+}
+
+func (g *Gateway) rangeNotSatisfiable(ctx context.Context, w http.ResponseWriter, r *http.Request, src downloadSource) {
+	size, err := src.size(ctx)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+}
+
+// size возвращает размер файла, а при version > 0 - размер этой версии
+func (g *Gateway) size(ctx context.Context, fileID string, version int32) (int64, error) {
+	if version == 0 {
+		file, err := g.client.GetFile(ctx, &pb.GetFileRequest{FileId: fileID})
+		if err != nil {
+			return 0, err
+		}
+		return file.Size, nil
+	}
+
+	resp, err := g.client.ListFileVersions(ctx, &pb.ListFileVersionsRequest{FileId: fileID})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range resp.Versions {
+		if v.Version == version {
+			return v.Size, nil
+		}
+	}
+	return 0, status.Error(codes.NotFound, "version not found")
+}
+
+// parseRange разбирает "bytes=a-b", "bytes=a-" и "bytes=-n"
+func parseRange(header string) (byteRange, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return byteRange{}, false
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return byteRange{}, false
+	}
+
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return byteRange{}, false
+		}
+		return byteRange{end: -1, suffix: n}, true
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false
+	}
+
+	if last == "" {
+		return byteRange{start: start, end: -1}, true
+	}
+
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < start {
+		return byteRange{}, false
+	}
+
+	return byteRange{start: start, end: end}, true
+}
+
+// etagMatch сравнивает If-None-Match со слабым сравнением (W/ игнорируется)
+func etagMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// setDigest передает суммы всего файла, в том числе в ответе на Range:
+// Repr-Digest (RFC 9530) с SHA-256 и те же суммы в hex, как в File
+func setDigest(h http.Header, file *pb.File) {
+	if sum, err := hex.DecodeString(file.Sha256); err == nil && len(sum) > 0 {
+		h.Set("Repr-Digest", fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(sum)))
+		h.Set("X-Checksum-Sha256", file.Sha256)
+	}
+	if file.Crc32C != "" {
+		h.Set("X-Checksum-Crc32c", file.Crc32C)
+	}
+}
+
+// activeContent сообщает, может ли браузер исполнить содержимое такого
+// типа: HTML, SVG, XML и скрипты отдаются только вложением
+func activeContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+
+	switch mediaType {
+	case "text/html", "application/xhtml+xml", "image/svg+xml", "text/xml", "application/xml",
+		"text/javascript", "application/javascript", "application/x-javascript",
+		"text/ecmascript", "application/ecmascript":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+xml")
+}
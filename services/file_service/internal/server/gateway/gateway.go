@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Размер чанка, которым http-тело перекладывается в grpc стрим
+const chunkSize = 64 << 10
+
+// errStreamClosed - сервер закрыл клиентский стрим раньше времени
+var errStreamClosed = errors.New("stream closed by server")
+
+// Gateway - http ручки, которые не выражаются через google.api.http аннотации
+type Gateway struct {
+	mux    *runtime.ServeMux
+	client pb.FileServiceClient
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, mux *runtime.ServeMux, client pb.FileServiceClient) *Gateway {
+	return &Gateway{
+		mux:    mux,
+		client: client,
+		logger: logger.WithFields("layer", "http gateway"),
+	}
+}
+
+func (g *Gateway) Register() error {
+	if err := g.mux.HandlePath(http.MethodPost, uploadPattern, g.upload); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodPost, uploadArchivePattern, g.uploadArchive); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodGet, downloadPattern, g.download); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodHead, downloadPattern, g.download); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodPatch, sessionPattern, g.writeSession); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodGet, archivePattern, g.downloadArchive); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodGet, sharePattern, g.downloadShared); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodHead, sharePattern, g.downloadShared); err != nil {
+		return err
+	}
+
+	if err := g.mux.HandlePath(http.MethodGet, shareInfoPattern, g.sharedFileInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// httpError пишет ошибку в том же формате, что и сгенерированные ручки
+func (g *Gateway) httpError(w http.ResponseWriter, r *http.Request, err error) {
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.HTTPError(r.Context(), g.mux, outbound, w, r, err)
+}
+
+// sendBody перекладывает body в grpc стрим чанками по chunkSize.
+// Ошибка send означает, что сервер закрыл стрим - причину
+// вызывающий получает из CloseAndRecv
+func sendBody(body io.Reader, send func(chunk []byte) error) error {
+	buf := make([]byte, chunkSize)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if err := send(buf[:n]); err != nil {
+				return errStreamClosed
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", readErr)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const sessionPattern = "/files/uploads/{session_id}"
+
+// writeSession принимает чанк сессии в tus-стиле: тело запроса - сырые байты,
+// позиция в заголовке Upload-Offset, длина в Content-Length
+func (g *Gateway) writeSession(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_WriteUploadSession_FullMethodName,
+		runtime.WithHTTPPathPattern(sessionPattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		g.httpError(w, r, status.Error(codes.InvalidArgument, "Upload-Offset header is required"))
+		return
+	}
+
+	if r.ContentLength < 0 {
+		g.httpError(w, r, status.Error(codes.InvalidArgument, "Content-Length header is required"))
+		return
+	}
+
+	stream, err := g.client.WriteUploadSession(ctx)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	// Ошибка Send означает, что сервер закрыл стрим - причина придет в CloseAndRecv
+	err = stream.Send(&pb.WriteUploadSessionRequest{
+		Data: &pb.WriteUploadSessionRequest_Header{
+			Header: &pb.UploadChunkHeader{
+				SessionId: pathParams["session_id"],
+				Offset:    offset,
+				Length:    r.ContentLength,
+			},
+		},
+	})
+	if err == nil {
+		err = sendBody(r.Body, func(chunk []byte) error {
+			return stream.Send(&pb.WriteUploadSessionRequest{
+				Data: &pb.WriteUploadSessionRequest_Chunk{Chunk: chunk},
+			})
+		})
+		if err != nil && !errors.Is(err, errStreamClosed) {
+			g.httpError(w, r, err)
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(resp.Offset, 10))
+
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.ForwardResponseMessage(ctx, g.mux, outbound, w, r, resp)
+}
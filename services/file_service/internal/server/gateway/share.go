@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	sharePattern     = "/s/{token}"
+	shareInfoPattern = "/s/{token}/info"
+)
+
+// downloadShared отдает файл по share-ссылке без авторизации.
+// Пароль передается только заголовком X-Share-Password, чтобы он не
+// попадал в логи и историю браузера
+func (g *Gateway) downloadShared(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_DownloadShared_FullMethodName,
+		runtime.WithHTTPPathPattern(sharePattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	token := pathParams["token"]
+
+	password, err := sharePassword(r)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	g.serveDownload(ctx, w, r, downloadSource{
+		name: "share link",
+		open: func(ctx context.Context, offset, length int64) (downloadStream, error) {
+			return g.client.DownloadShared(ctx, &pb.DownloadSharedRequest{
+				Token:    token,
+				Password: password,
+				Offset:   offset,
+				Length:   length,
+			})
+		},
+		size: func(ctx context.Context) (int64, error) {
+			file, err := g.client.GetSharedFile(ctx, &pb.GetSharedFileRequest{
+				Token:    token,
+				Password: password,
+			})
+			if err != nil {
+				return 0, err
+			}
+			return file.Size, nil
+		},
+	})
+}
+
+// sharedFileInfo отдает метаданные файла по share-ссылке. Ручка своя,
+// а не сгенерированная: та приняла бы пароль и из query-параметра
+func (g *Gateway) sharedFileInfo(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_GetSharedFile_FullMethodName,
+		runtime.WithHTTPPathPattern(shareInfoPattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	password, err := sharePassword(r)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	resp, err := g.client.GetSharedFile(ctx, &pb.GetSharedFileRequest{
+		Token:    pathParams["token"],
+		Password: password,
+	})
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	_, outbound := runtime.MarshalerForRequest(g.mux, r)
+	runtime.ForwardResponseMessage(ctx, g.mux, outbound, w, r, resp)
+}
+
+// sharePassword возвращает пароль share-ссылки из заголовка и отклоняет
+// запрос с паролем в query, чтобы клиенты не передавали его в URL
+func sharePassword(r *http.Request) (string, error) {
+	if r.URL.Query().Has("password") {
+		return "", status.Error(codes.InvalidArgument, "share link password must be sent in the X-Share-Password header")
+	}
+	return r.Header.Get("X-Share-Password"), nil
+}
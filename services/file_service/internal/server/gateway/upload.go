@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const uploadPattern = "/files/upload/stream"
+
+// Максимальный размер текстового поля формы
+const maxFieldSize = 1 << 10
+
+// upload принимает multipart/form-data: поля course_id, group_id, folder_id,
+// mime_type, file_id (для новой версии), sha256, crc32c, size и часть file,
+// которая должна идти последней
+func (g *Gateway) upload(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		g.mux,
+		r,
+		pb.FileService_UploadFile_FullMethodName,
+		runtime.WithHTTPPathPattern(uploadPattern),
+	)
+	if err != nil {
+		g.httpError(w, r, err)
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		g.httpError(w, r, status.Errorf(codes.InvalidArgument, "expected multipart/form-data: %v", err))
+		return
+	}
+
+	meta := &pb.FileMetadata{}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			g.httpError(w, r, status.Error(codes.InvalidArgument, "file part is missing"))
+			return
+		}
+		if err != nil {
+			g.httpError(w, r, status.Errorf(codes.InvalidArgument, "malformed multipart body: %v", err))
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFieldSize))
+			if err != nil {
+				g.httpError(w, r, status.Errorf(codes.InvalidArgument, "failed to read field %s: %v", part.FormName(), err))
+				return
+			}
+
+			switch part.FormName() {
+			case "course_id":
+				meta.CourseId = string(value)
+			case "group_id":
+				meta.GroupId = string(value)
+			case "mime_type":
+				meta.MimeType = string(value)
+			case "filename":
+				meta.Filename = string(value)
+			case "folder_id":
+				meta.FolderId = string(value)
+			case "file_id":
+				meta.FileId = string(value)
+			case "sha256":
+				meta.Sha256 = string(value)
+			case "crc32c":
+				meta.Crc32C = string(value)
+			case "size":
+				meta.Size, err = strconv.ParseInt(string(value), 10, 64)
+				if err != nil {
+					g.httpError(w, r, status.Error(codes.InvalidArgument, "invalid size"))
+					return
+				}
+			}
+			continue
+		}
+
+		if meta.Filename == "" {
+			meta.Filename = part.FileName()
+		}
+		if meta.MimeType == "" {
+			meta.MimeType = part.Header.Get("Content-Type")
+		}
+
+		resp, err := g.streamUpload(ctx, meta, part)
+		if err != nil {
+			g.httpError(w, r, err)
+			return
+		}
+
+		_, outbound := runtime.MarshalerForRequest(g.mux, r)
+		runtime.ForwardResponseMessage(ctx, g.mux, outbound, w, r, resp)
+		return
+	}
+}
+
+func (g *Gateway) streamUpload(
+	ctx context.Context,
+	meta *pb.FileMetadata,
+	body io.Reader,
+) (*pb.UploadFileResponse, error) {
+
+	stream, err := g.client.UploadFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&pb.UploadFileRequest{
+		Data: &pb.UploadFileRequest_Metadata{Metadata: meta},
+	})
+	if err != nil {
+		// настоящая причина придет в CloseAndRecv
+		_, err = stream.CloseAndRecv()
+		return nil, err
+	}
+
+	err = sendBody(body, func(chunk []byte) error {
+		return stream.Send(&pb.UploadFileRequest{
+			Data: &pb.UploadFileRequest_Chunk{Chunk: chunk},
+		})
+	})
+	if err != nil && !errors.Is(err, errStreamClosed) {
+		return nil, err
+	}
+
+	return stream.CloseAndRecv()
+}
@@ -1,6 +1,11 @@
 package grpc
 
+import "github.com/alexey-dobry/fileshare/services/file_service/internal/server/grpc/public"
+
 type Config struct {
 	PublicPort  string `validate:"required" yaml:"public_port"`
 	GatewayPort string `validate:"required" yaml:"gateway_port"`
+	JWTSecret   string `validate:"required" yaml:"jwt_key"`
+
+	Public public.Config `yaml:"public"`
 }
@@ -3,15 +3,37 @@ package grpc
 import (
 	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
 	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/server/grpc/middleware"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/server/grpc/public"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
 
 	"google.golang.org/grpc"
 )
 
-func NewPublicServer(logger logger.Logger, repository store.Store) *grpc.Server {
-	s := grpc.NewServer()
+// Методы share-ссылок вызываются без токена, доступ дает сама ссылка
+var anonymousMethods = []string{
+	pb.FileService_GetSharedFile_FullMethodName,
+	pb.FileService_DownloadShared_FullMethodName,
+}
+
+func NewPublicServer(
+	logger logger.Logger,
+	repository store.Store,
+	cfg Config,
+	checker *access.Checker,
+	policy *policy.Policy,
+) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			middleware.NewJWTAuthUnaryInterceptor(cfg.JWTSecret, anonymousMethods...),
+		),
+		grpc.StreamInterceptor(
+			middleware.NewJWTAuthStreamInterceptor(cfg.JWTSecret, anonymousMethods...),
+		),
+	)
 
-	pb.RegisterFileServiceServer(s, public.New(logger, repository))
+	pb.RegisterFileServiceServer(s, public.New(logger, repository, checker, policy, cfg.Public))
 	return s
 }
@@ -4,15 +4,19 @@ import (
 	"context"
 	"strings"
 
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-func NewJWTAuthUnaryInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
+// anonymous - методы, которые вызываются без токена
+func NewJWTAuthUnaryInterceptor(jwtSecret string, anonymous ...string) grpc.UnaryServerInterceptor {
 	secret := []byte(jwtSecret)
+	skip := methodSet(anonymous)
 
 	return func(
 		ctx context.Context,
@@ -21,35 +25,103 @@ func NewJWTAuthUnaryInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "metadata is missing")
+		if _, ok := skip[info.FullMethod]; ok {
+			return handler(ctx, req)
 		}
 
-		authHeader := md.Get("authorization")
-		if len(authHeader) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "authorization header is missing")
+		ctx, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
 		}
 
-		tokenString := authHeader[0]
-		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Проверяем алгоритм
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, status.Error(codes.Unauthenticated, "unexpected signing method")
-			}
-			return secret, nil
-		})
-		if err != nil || !token.Valid {
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		return handler(ctx, req)
+	}
+}
+
+func NewJWTAuthStreamInterceptor(jwtSecret string, anonymous ...string) grpc.StreamServerInterceptor {
+	secret := []byte(jwtSecret)
+	skip := methodSet(anonymous)
+
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		if _, ok := skip[info.FullMethod]; ok {
+			return handler(srv, ss)
 		}
 
-		_, ok = token.Claims.(jwt.MapClaims)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+		ctx, err := authenticate(ss.Context(), secret)
+		if err != nil {
+			return err
 		}
 
-		return handler(ctx, req)
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func methodSet(methods []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		set[m] = struct{}{}
 	}
+	return set
+}
+
+// authStream подменяет контекст стрима на контекст с пользователем
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate проверяет JWT и кладет пользователя из claims в контекст
+func authenticate(ctx context.Context, secret []byte) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata is missing")
+	}
+
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization header is missing")
+	}
+
+	tokenString := authHeader[0]
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Проверяем алгоритм
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, status.Error(codes.Unauthenticated, "unexpected signing method")
+		}
+		return secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+	}
+
+	rawID, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "token has no valid user id")
+	}
+
+	role, _ := claims["role"].(string)
+
+	return utils.ContextWithCaller(ctx, utils.Caller{
+		ID:    userID,
+		Role:  role,
+		Token: tokenString,
+	}), nil
 }
@@ -0,0 +1,133 @@
+package public
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fileFor находит файл и проверяет право вызывающего на action
+func (s *PublicServer) fileFor(ctx context.Context, id string, action access.Action) (*model.File, error) {
+	fileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid file id")
+	}
+
+	file, err := s.store.Meta().GetByID(fileID.String())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	if err := s.access.Authorize(ctx, action, access.FileResource(file)); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// maxScopeIDLen - предел длины ID курса и группы
+const maxScopeIDLen = 64
+
+// scopeID проверяет ID курса или группы (kind). ID выдает user_service
+// (там это SERIAL), поэтому здесь они непрозрачные непустые строки
+func scopeID(id string, kind string) (string, error) {
+	if id == "" || len(id) > maxScopeIDLen || strings.IndexFunc(id, unicode.IsSpace) >= 0 {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s id", kind)
+	}
+	return id, nil
+}
+
+// checkScope проверяет необязательные ID курса и группы
+func checkScope(courseID, groupID string) error {
+	if courseID != "" {
+		if _, err := scopeID(courseID, "course"); err != nil {
+			return err
+		}
+	}
+	if groupID != "" {
+		if _, err := scopeID(groupID, "group"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadDest - куда попадет загрузка
+type uploadDest struct {
+	// targetID - файл, новой версией которого станет загрузка
+	targetID string
+
+	courseID string
+	groupID  string
+	folderID string
+}
+
+// resolveUpload проверяет право на загрузку и определяет, куда она попадет:
+// новой версией файла fileID, в папку folderID либо в курс и группу
+func (s *PublicServer) resolveUpload(ctx context.Context, fileID, folderID, courseID, groupID string) (uploadDest, error) {
+	if fileID != "" {
+		file, err := s.fileFor(ctx, fileID, access.Update)
+		if err != nil {
+			return uploadDest{}, err
+		}
+
+		return uploadDest{
+			targetID: file.UUID,
+			courseID: file.CourseID,
+			groupID:  file.GroupID,
+			folderID: file.FolderID,
+		}, nil
+	}
+
+	if folderID != "" {
+		folder, err := s.folderFor(ctx, folderID, access.Upload)
+		if err != nil {
+			return uploadDest{}, err
+		}
+
+		return uploadDest{
+			courseID: folder.CourseID,
+			groupID:  folder.GroupID,
+			folderID: folder.UUID,
+		}, nil
+	}
+
+	if err := checkScope(courseID, groupID); err != nil {
+		return uploadDest{}, err
+	}
+
+	err := s.access.Authorize(ctx, access.Upload, access.Resource{
+		CourseID: courseID,
+		GroupID:  groupID,
+	})
+	if err != nil {
+		return uploadDest{}, err
+	}
+
+	return uploadDest{courseID: courseID, groupID: groupID}, nil
+}
+
+// folderFor находит папку и проверяет право вызывающего на action
+func (s *PublicServer) folderFor(ctx context.Context, id string, action access.Action) (*model.Folder, error) {
+	folderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid folder id")
+	}
+
+	folder, err := s.store.Folders().GetByID(folderID.String())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "folder not found")
+	}
+
+	if err := s.access.Authorize(ctx, action, access.FolderResource(folder)); err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
@@ -0,0 +1,357 @@
+package public
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultArchiveName = "files"
+
+// DownloadArchive собирает ZIP на лету: содержимое файлов читается из
+// хранилища и сжимается прямо в стрим, ничего не складывая на диск
+func (s *PublicServer) DownloadArchive(
+	req *pb.DownloadArchiveRequest,
+	stream pb.FileService_DownloadArchiveServer,
+) error {
+
+	name, err := archiveName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	var files []*model.File
+	var dirs map[string]string
+	var skipped int
+
+	switch {
+	case len(req.FileIds) > 0:
+		if req.CourseId != "" || req.GroupId != "" || req.FolderId != "" {
+			return status.Error(codes.InvalidArgument, "file ids cannot be combined with folder, course or group")
+		}
+		files, err = s.archiveFilesByID(stream.Context(), req.FileIds)
+	case req.FolderId != "":
+		if req.CourseId != "" || req.GroupId != "" {
+			return status.Error(codes.InvalidArgument, "folder id cannot be combined with course or group")
+		}
+		files, dirs, skipped, err = s.archiveFilesByFolder(stream.Context(), req.FolderId)
+	default:
+		files, skipped, err = s.archiveFilesByScope(stream.Context(), req.CourseId, req.GroupId)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return status.Error(codes.NotFound, "no files to archive")
+	}
+
+	var size int64
+	for _, file := range files {
+		size += file.Size
+	}
+	if s.cfg.ArchiveMaxSize > 0 && size > s.cfg.ArchiveMaxSize {
+		return status.Errorf(codes.FailedPrecondition,
+			"archive size %d exceeds the limit of %d bytes", size, s.cfg.ArchiveMaxSize)
+	}
+
+	err = stream.Send(&pb.DownloadArchiveResponse{
+		Data: &pb.DownloadArchiveResponse_Header{
+			Header: &pb.DownloadArchiveHeader{
+				Name:      name,
+				FileCount: int32(len(files)),
+				Size:      size,
+				Skipped:   int32(skipped),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.writeArchive(stream, files, dirs)
+}
+
+// archiveFilesByID проверяет право на скачивание каждого файла.
+// Явно запрошенный файл, который нельзя отдать, - ошибка всего запроса
+func (s *PublicServer) archiveFilesByID(ctx context.Context, ids []string) ([]*model.File, error) {
+	files := make([]*model.File, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		file, err := s.fileFor(ctx, id, access.Download)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[file.UUID] {
+			continue
+		}
+		seen[file.UUID] = true
+
+		if err := checkContent(file); err != nil {
+			return nil, status.Errorf(status.Code(err), "%s: %s", file.Name, status.Convert(err).Message())
+		}
+
+		files = append(files, file)
+		if s.cfg.ArchiveMaxFiles > 0 && len(files) > s.cfg.ArchiveMaxFiles {
+			return nil, status.Errorf(codes.FailedPrecondition, "archive can hold at most %d files", s.cfg.ArchiveMaxFiles)
+		}
+	}
+
+	return files, nil
+}
+
+// archiveFilesByScope выбирает доступные файлы курса и/или группы
+func (s *PublicServer) archiveFilesByScope(ctx context.Context, courseID, groupID string) ([]*model.File, int, error) {
+	if courseID == "" && groupID == "" {
+		return nil, 0, status.Error(codes.InvalidArgument, "file ids, folder id, course id or group id is required")
+	}
+
+	query := model.FileQuery{SortBy: model.SortByName}
+
+	if err := checkScope(courseID, groupID); err != nil {
+		return nil, 0, err
+	}
+	query.CourseID = courseID
+	query.GroupID = groupID
+
+	scope := access.Resource{CourseID: query.CourseID, GroupID: query.GroupID}
+	if err := s.access.Authorize(ctx, access.List, scope); err != nil {
+		return nil, 0, err
+	}
+
+	return s.archiveFiles(ctx, query)
+}
+
+// archiveFilesByFolder выбирает доступные файлы папки и всех ее подпапок,
+// dirs - путь каждой папки внутри архива относительно folderID
+func (s *PublicServer) archiveFilesByFolder(
+	ctx context.Context,
+	folderID string,
+) (files []*model.File, dirs map[string]string, skipped int, err error) {
+
+	root, err := s.folderFor(ctx, folderID, access.List)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	folders, err := s.store.Folders().Subtree(root.UUID)
+	if err != nil {
+		return nil, nil, 0, status.Error(codes.Internal, "db error")
+	}
+
+	dirs = archiveDirs(root.UUID, folders)
+
+	query := model.FileQuery{SortBy: model.SortByName}
+	for id := range dirs {
+		query.FolderIDs = append(query.FolderIDs, id)
+	}
+
+	files, skipped, err = s.archiveFiles(ctx, query)
+	return files, dirs, skipped, err
+}
+
+// archiveFiles выбирает файлы query, которые вызывающий может скачать.
+// Непроверенные и зараженные файлы пропускаются
+func (s *PublicServer) archiveFiles(ctx context.Context, query model.FileQuery) ([]*model.File, int, error) {
+	// Limit 0 в gorm дает LIMIT 0, -1 снимает ограничение
+	query.Limit = -1
+	if s.cfg.ArchiveMaxFiles > 0 {
+		query.Limit = s.cfg.ArchiveMaxFiles + 1
+	}
+
+	found, _, err := s.store.Meta().List(query)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, "db error")
+	}
+	if s.cfg.ArchiveMaxFiles > 0 && len(found) > s.cfg.ArchiveMaxFiles {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "archive can hold at most %d files", s.cfg.ArchiveMaxFiles)
+	}
+
+	files := make([]*model.File, 0, len(found))
+	var skipped int
+
+	for _, file := range found {
+		// Файлы, которые нельзя скачать, не раскрываются даже счетчиком
+		if err := s.access.Authorize(ctx, access.Download, access.FileResource(file)); err != nil {
+			continue
+		}
+
+		if checkContent(file) != nil {
+			skipped++
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	return files, skipped, nil
+}
+
+func (s *PublicServer) writeArchive(
+	stream pb.FileService_DownloadArchiveServer,
+	files []*model.File,
+	dirs map[string]string,
+) error {
+
+	out := bufio.NewWriterSize(archiveWriter{stream: stream}, downloadChunkSize)
+	zw := zip.NewWriter(out)
+
+	for i, name := range archiveNames(files, dirs) {
+		file := files[i]
+
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   archiveMethod(file.MimeType),
+			Modified: file.CreatedAt,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "archive write failed: %v", err)
+		}
+
+		reader, err := s.store.File().Get(file.StorageKey)
+		if err != nil {
+			return status.Errorf(codes.Internal, "storage error: %v", err)
+		}
+
+		_, err = io.Copy(w, reader)
+		reader.Close()
+		if err != nil {
+			return status.Errorf(codes.Internal, "archive write failed: %v", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return status.Errorf(codes.Internal, "archive write failed: %v", err)
+	}
+	return out.Flush()
+}
+
+// archiveWriter отправляет записанные байты в стрим чанком
+type archiveWriter struct {
+	stream pb.FileService_DownloadArchiveServer
+}
+
+func (w archiveWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&pb.DownloadArchiveResponse{
+		Data: &pb.DownloadArchiveResponse_Chunk{Chunk: p},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// archiveNames дает файлам уникальные пути внутри архива: файл кладется
+// в каталог своей папки из dirs, повтор "report.pdf" становится
+// "report (1).pdf". Регистр не учитывается, иначе на Windows файлы
+// перезапишут друг друга при распаковке
+func archiveNames(files []*model.File, dirs map[string]string) []string {
+	names := make([]string, 0, len(files))
+	used := make(map[string]bool, len(files)+len(dirs))
+
+	// Файл не должен совпасть по имени с каталогом рядом с ним
+	for _, dir := range dirs {
+		used[strings.ToLower(strings.TrimSuffix(dir, "/"))] = true
+	}
+
+	for _, file := range files {
+		names = append(names, archiveEntry(dirs[file.FolderID], file.Name, file.UUID, used))
+	}
+
+	return names
+}
+
+// archiveDirs строит пути папок внутри архива: корень rootID - сам
+// архив, подпапки - каталоги с уникальными среди соседей именами
+func archiveDirs(rootID string, folders []*model.Folder) map[string]string {
+	children := make(map[string][]*model.Folder, len(folders))
+	for _, folder := range folders {
+		if folder.UUID != rootID {
+			children[folder.ParentID] = append(children[folder.ParentID], folder)
+		}
+	}
+
+	dirs := map[string]string{rootID: ""}
+	used := make(map[string]bool, len(folders))
+
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		sub := children[parent]
+		slices.SortFunc(sub, func(a, b *model.Folder) int { return strings.Compare(a.Name, b.Name) })
+
+		for _, folder := range sub {
+			dirs[folder.UUID] = archiveEntry(dirs[parent], folder.Name, folder.UUID, used) + "/"
+			queue = append(queue, folder.UUID)
+		}
+	}
+
+	return dirs
+}
+
+// archiveEntry возвращает незанятый путь dir + name, недопустимое
+// имя заменяется на fallback
+func archiveEntry(dir string, name string, fallback string, used map[string]bool) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_")
+
+	name = replacer.Replace(strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		name = fallback
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	candidate := dir + name
+	for n := 1; used[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s%s (%d)%s", dir, base, n, ext)
+	}
+
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// archiveMethod не тратит время на сжатие уже сжатых форматов
+func archiveMethod(mimeType string) uint16 {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"),
+		strings.HasPrefix(mimeType, "video/"),
+		strings.HasPrefix(mimeType, "audio/"),
+		mimeType == "application/zip",
+		mimeType == "application/gzip",
+		mimeType == "application/x-7z-compressed",
+		mimeType == "application/vnd.rar":
+		return zip.Store
+	default:
+		return zip.Deflate
+	}
+}
+
+// archiveName проверяет имя архива и добавляет расширение
+func archiveName(name string) (string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".zip")
+	if name == "" {
+		return defaultArchiveName + ".zip", nil
+	}
+
+	name, err := fileName(name + ".zip")
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,186 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/content"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/preview"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errUnknownContent - клиент сослался на хеш, которого нет в хранилище
+var errUnknownContent = errors.New("unknown content")
+
+// blobKey - ключ содержимого курса courseID. Дедупликация идет внутри
+// курса: blob зашифрован ключом своего курса
+func blobKey(courseID string, hash string) string {
+	if courseID == "" {
+		return fmt.Sprintf("blobs/%s", hash)
+	}
+	return fmt.Sprintf("blobs/%s/%s", url.PathEscape(courseID), hash)
+}
+
+// parseChecksum проверяет SHA-256 и CRC32C в hex, присланные клиентом.
+// Обе суммы необязательны
+func parseChecksum(sha256, crc32c string) (checksum.Sum, error) {
+	sum, ok := checksum.Valid(sha256, crc32c)
+	if !ok {
+		return checksum.Sum{}, status.Error(codes.InvalidArgument, "sha256 must be 64 and crc32c 8 hex characters")
+	}
+
+	return sum, nil
+}
+
+// verifyChecksum сверяет суммы, заявленные клиентом, с посчитанными
+func verifyChecksum(expected checksum.Sum, actual checksum.Sum) error {
+	switch expected.Mismatch(actual) {
+	case "sha256":
+		return status.Errorf(codes.InvalidArgument, "sha256 mismatch: content hashes to %s", actual.SHA256)
+	case "crc32c":
+		return status.Errorf(codes.InvalidArgument, "crc32c mismatch: content checksum is %s", actual.CRC32C)
+	}
+	return nil
+}
+
+// linkExisting добавляет ссылку на уже хранящееся в курсе содержимое
+func (s *PublicServer) linkExisting(courseID string, sum checksum.Sum) (*model.Blob, error) {
+	blob, err := s.store.Blobs().Link(sum.SHA256, "", blobKey(courseID, sum.SHA256), 0, func(string) error {
+		return errUnknownContent
+	})
+	if errors.Is(err, errUnknownContent) {
+		return nil, status.Error(codes.FailedPrecondition, "content with this sha256 is unknown, upload it")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "blob link failed: %v", err)
+	}
+
+	if err := verifyChecksum(sum, checksum.Sum{SHA256: blob.Hash, CRC32C: blob.CRC32C}); err != nil {
+		if releaseErr := s.releaseContent(blob.Hash, blob.StorageKey); releaseErr != nil {
+			s.logger.Warnf("failed to release blob %s: %s", blob.Hash, releaseErr)
+		}
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+// linkBlob переносит загруженный объект stagingKey в blob курса courseID.
+// Промежуточный объект записан ключом того же курса, поэтому копируется
+// как есть. Промежуточный объект удаляется в любом случае
+func (s *PublicServer) linkBlob(courseID string, stagingKey string, sum checksum.Sum, size int64) (*model.Blob, error) {
+	blob, err := s.store.Blobs().Link(sum.SHA256, sum.CRC32C, blobKey(courseID, sum.SHA256), size, func(key string) error {
+		return s.store.File().Copy(stagingKey, key)
+	})
+
+	s.dropObject(stagingKey)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "blob link failed: %v", err)
+	}
+
+	return blob, nil
+}
+
+// relinkContent ссылается на содержимое версии v из курса courseID и
+// возвращает его ключ. Если в курсе такого blob еще нет (содержимое
+// другого курса зашифровано чужим ключом), оно перешифровывается в
+// новый blob, а объект без хеша - в новый объект
+func (s *PublicServer) relinkContent(courseID string, v *model.FileVersion) (string, error) {
+	if v.Hash == "" {
+		key := fmt.Sprintf("files/%s", uuid.New())
+		if err := s.sealContent(courseID, v.StorageKey, key, v.MimeType, v.Size); err != nil {
+			s.dropObject(key)
+			return "", status.Errorf(codes.Internal, "content copy failed: %v", err)
+		}
+		return key, nil
+	}
+
+	blob, err := s.store.Blobs().Link(v.Hash, v.CRC32C, blobKey(courseID, v.Hash), v.Size, func(key string) error {
+		return s.sealContent(courseID, v.StorageKey, key, v.MimeType, v.Size)
+	})
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "blob link failed: %v", err)
+	}
+
+	return blob.StorageKey, nil
+}
+
+// sealContent переписывает объект srcKey в dstKey ключом курса courseID
+// вместе с миниатюрой, если она есть
+func (s *PublicServer) sealContent(courseID string, srcKey string, dstKey string, mimeType string, size int64) error {
+	if err := s.sealObject(courseID, srcKey, dstKey, mimeType, size); err != nil {
+		return err
+	}
+
+	err := s.sealObject(courseID, preview.Key(srcKey), preview.Key(dstKey), preview.MimeType, -1)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *PublicServer) sealObject(courseID string, srcKey string, dstKey string, mimeType string, size int64) error {
+	reader, err := s.store.File().Get(srcKey)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return s.store.CourseFile(courseID).Put(dstKey, reader, size, mimeType)
+}
+
+// dropObject удаляет объект незавершенной загрузки. Ошибка только
+// логируется: оставшийся объект найдет и удалит сверка хранилища
+func (s *PublicServer) dropObject(key string) {
+	if err := s.store.File().Delete(key); err != nil {
+		s.logger.Warnf("failed to delete object %s: %s", key, err)
+	}
+}
+
+// sumObject считает суммы объекта, загруженного в обход потока сервиса
+func (s *PublicServer) sumObject(key string) (checksum.Sum, error) {
+	reader, err := s.store.File().Get(key)
+	if err != nil {
+		return checksum.Sum{}, status.Errorf(codes.Internal, "storage error: %v", err)
+	}
+	defer reader.Close()
+
+	sum, err := checksum.Read(reader)
+	if err != nil {
+		return checksum.Sum{}, status.Errorf(codes.Internal, "read failed: %v", err)
+	}
+
+	return sum, nil
+}
+
+// releaseContent убирает ссылку на содержимое
+func (s *PublicServer) releaseContent(hash string, key string) error {
+	return content.Release(s.store, hash, key)
+}
+
+// saveFile сохраняет метаданные файла, при ошибке отпуская его blob
+func (s *PublicServer) saveFile(file *model.File) error {
+	if err := s.store.Meta().Create(file); err != nil {
+		if unlinkErr := s.releaseContent(file.Hash, file.StorageKey); unlinkErr != nil {
+			s.logger.Warnf("failed to release blob of file %s: %s", file.UUID, unlinkErr)
+		}
+		return writeError(err)
+	}
+
+	return nil
+}
+
+// writeError переводит ошибку записи метаданных файла в статус
+func writeError(err error) error {
+	if errors.Is(err, store.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+	}
+	return status.Errorf(codes.Internal, "db write failed: %v", err)
+}
@@ -0,0 +1,27 @@
+package public
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/unzip"
+)
+
+type Config struct {
+	SessionTTL time.Duration `yaml:"session_ttl" env-default:"24h"`
+	PresignTTL time.Duration `yaml:"presign_ttl" env-default:"15m"`
+
+	// Сколько версий файла хранить, 0 - без ограничения
+	VersionRetention int `yaml:"version_retention" env-default:"10"`
+
+	// Ограничения DownloadArchive: суммарный размер файлов и их количество
+	ArchiveMaxSize  int64 `yaml:"archive_max_size" env-default:"2147483648"`
+	ArchiveMaxFiles int   `yaml:"archive_max_files" env-default:"500"`
+
+	// Сколько раз за окно можно проверить пароль share-ссылки,
+	// 0 - без ограничения
+	SharePasswordAttempts int32         `yaml:"share_password_attempts" env-default:"10"`
+	SharePasswordWindow   time.Duration `yaml:"share_password_window" env-default:"15m"`
+
+	// Ограничения распаковки UploadArchive
+	Unpack unzip.Config `yaml:"unpack"`
+}
@@ -0,0 +1,182 @@
+package public
+
+import (
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func fileToProto(file *model.File) *pb.File {
+	f := &pb.File{
+		Id:         file.UUID,
+		Name:       file.Name,
+		MimeType:   file.MimeType,
+		Size:       file.Size,
+		UploaderId: file.UploaderID,
+		CourseId:   file.CourseID,
+		GroupId:    file.GroupID,
+		FolderId:   file.FolderID,
+		CreatedAt:  timestamppb.New(file.CreatedAt),
+		Sha256:     file.Hash,
+		Crc32C:     file.CRC32C,
+		Version:    file.CurrentVersion,
+
+		ScanStatus:    scanStatusToProto(file.ScanStatus),
+		ScanSignature: file.ScanSignature,
+		HasPreview:    file.PreviewState == model.PreviewReady,
+
+		Tags:     file.Tags,
+		Metadata: file.Metadata,
+
+		ContentMissing: file.ContentMissing,
+	}
+
+	if file.DeletedAt.Valid {
+		f.DeletedAt = timestamppb.New(file.DeletedAt.Time)
+	}
+
+	return f
+}
+
+func filesToProto(files []*model.File) []*pb.File {
+	f := make([]*pb.File, 0, len(files))
+	for _, file := range files {
+		f = append(f, fileToProto(file))
+	}
+	return f
+}
+
+func sessionToProto(session *model.UploadSession) *pb.UploadSession {
+	return &pb.UploadSession{
+		Id:        session.UUID,
+		Offset:    session.Offset,
+		Size:      session.Size,
+		ChunkSize: sessionPartSize,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}
+}
+
+// versionsToProto ожидает версии от новых к старым и сравнивает
+// каждую с предыдущей по номеру
+func versionsToProto(versions []*model.FileVersion) []*pb.FileVersion {
+	v := make([]*pb.FileVersion, 0, len(versions))
+	for i, version := range versions {
+		var prev *model.FileVersion
+		if i+1 < len(versions) {
+			prev = versions[i+1]
+		}
+
+		pv := &pb.FileVersion{
+			Version:        version.Version,
+			MimeType:       version.MimeType,
+			Size:           version.Size,
+			Sha256:         version.Hash,
+			Crc32C:         version.CRC32C,
+			UploaderId:     version.UploaderID,
+			CreatedAt:      timestamppb.New(version.CreatedAt),
+			SizeDelta:      version.Size,
+			ContentChanged: true,
+			ScanStatus:     scanStatusToProto(version.ScanStatus),
+		}
+		if prev != nil {
+			pv.SizeDelta = version.Size - prev.Size
+			pv.ContentChanged = version.StorageKey != prev.StorageKey
+		}
+
+		v = append(v, pv)
+	}
+	return v
+}
+
+func usageToProto(usage *model.Usage) *pb.Usage {
+	return &pb.Usage{
+		Used:  usage.Bytes,
+		Limit: usage.Limit,
+	}
+}
+
+func folderToProto(folder *model.Folder) *pb.Folder {
+	return &pb.Folder{
+		Id:        folder.UUID,
+		Name:      folder.Name,
+		ParentId:  folder.ParentID,
+		CourseId:  folder.CourseID,
+		GroupId:   folder.GroupID,
+		CreatorId: folder.CreatorID,
+		CreatedAt: timestamppb.New(folder.CreatedAt),
+	}
+}
+
+func foldersToProto(folders []*model.Folder) []*pb.Folder {
+	f := make([]*pb.Folder, 0, len(folders))
+	for _, folder := range folders {
+		f = append(f, folderToProto(folder))
+	}
+	return f
+}
+
+// sharedFileToProto отдает только то, что нужно получателю share-ссылки
+func sharedFileToProto(file *model.File) *pb.File {
+	return &pb.File{
+		Id:        file.UUID,
+		Name:      file.Name,
+		MimeType:  file.MimeType,
+		Size:      file.Size,
+		CreatedAt: timestamppb.New(file.CreatedAt),
+		Sha256:    file.Hash,
+		Version:   file.CurrentVersion,
+	}
+}
+
+func shareLinkToProto(link *model.ShareLink) *pb.ShareLink {
+	l := &pb.ShareLink{
+		Id:           link.UUID,
+		Token:        link.Token,
+		FileId:       link.FileUUID,
+		HasPassword:  link.PasswordHash != "",
+		MaxDownloads: link.MaxDownloads,
+		Downloads:    link.Downloads,
+		CreatorId:    link.CreatorID,
+		CreatedAt:    timestamppb.New(link.CreatedAt),
+	}
+
+	if link.ExpiresAt != nil {
+		l.ExpiresAt = timestamppb.New(*link.ExpiresAt)
+	}
+
+	return l
+}
+
+func shareLinksToProto(links []*model.ShareLink) []*pb.ShareLink {
+	l := make([]*pb.ShareLink, 0, len(links))
+	for _, link := range links {
+		l = append(l, shareLinkToProto(link))
+	}
+	return l
+}
+
+func scanStatusToProto(scanStatus string) pb.ScanStatus {
+	switch scanStatus {
+	case model.ScanClean:
+		return pb.ScanStatus_SCAN_STATUS_CLEAN
+	case model.ScanInfected:
+		return pb.ScanStatus_SCAN_STATUS_INFECTED
+	case model.ScanFailed:
+		return pb.ScanStatus_SCAN_STATUS_FAILED
+	default:
+		return pb.ScanStatus_SCAN_STATUS_PENDING
+	}
+}
+
+func searchHitsToProto(hits []*model.SearchHit) []*pb.SearchHit {
+	h := make([]*pb.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		h = append(h, &pb.SearchHit{
+			File:          fileToProto(&hit.File),
+			Rank:          hit.Rank,
+			NameHighlight: highlight(hit.NameHighlight),
+			Snippet:       highlight(hit.Snippet),
+		})
+	}
+	return h
+}
@@ -0,0 +1,213 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxFolderNameLength = 255
+
+func (s *PublicServer) CreateFolder(
+	ctx context.Context,
+	req *pb.CreateFolderRequest,
+) (*pb.Folder, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := folderName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Папка создается там же, куда можно загружать файлы
+	dest, err := s.resolveUpload(ctx, "", req.ParentId, req.CourseId, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := &model.Folder{
+		UUID:      uuid.New().String(),
+		Name:      name,
+		ParentID:  dest.folderID,
+		CourseID:  dest.courseID,
+		GroupID:   dest.groupID,
+		CreatorID: userID.String(),
+	}
+
+	if err := s.store.Folders().Create(folder); err != nil {
+		return nil, status.Errorf(codes.Internal, "db insert failed: %v", err)
+	}
+
+	return folderToProto(folder), nil
+}
+
+func (s *PublicServer) RenameFolder(
+	ctx context.Context,
+	req *pb.RenameFolderRequest,
+) (*pb.Folder, error) {
+
+	name, err := folderName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := s.folderFor(ctx, req.FolderId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Folders().Rename(folder.UUID, name); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+	folder.Name = name
+
+	return folderToProto(folder), nil
+}
+
+func (s *PublicServer) MoveFolder(
+	ctx context.Context,
+	req *pb.MoveFolderRequest,
+) (*pb.Folder, error) {
+
+	folder, err := s.folderFor(ctx, req.FolderId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID string
+	if req.ParentId != "" {
+		parent, err := s.folderFor(ctx, req.ParentId, access.Upload)
+		if err != nil {
+			return nil, err
+		}
+
+		// Содержимое папки учтено в квоте курса, поэтому папки
+		// не переезжают между курсами и группами
+		if parent.CourseID != folder.CourseID || parent.GroupID != folder.GroupID {
+			return nil, status.Error(codes.FailedPrecondition, "folder can only be moved within its course and group")
+		}
+		parentID = parent.UUID
+	}
+
+	err = s.store.Folders().Move(folder.UUID, parentID)
+	if errors.Is(err, store.ErrFolderCycle) {
+		return nil, status.Error(codes.InvalidArgument, "folder cannot be moved into itself")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+	folder.ParentID = parentID
+
+	return folderToProto(folder), nil
+}
+
+func (s *PublicServer) DeleteFolder(
+	ctx context.Context,
+	req *pb.DeleteFolderRequest,
+) (*pb.DeleteFolderResponse, error) {
+
+	folder, err := s.folderFor(ctx, req.FolderId, access.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	filesDeleted, err := s.store.Folders().Delete(folder.UUID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "db delete failed: %v", err)
+	}
+
+	return &pb.DeleteFolderResponse{
+		Success:      true,
+		FilesDeleted: int32(filesDeleted),
+	}, nil
+}
+
+func (s *PublicServer) ListFolder(
+	ctx context.Context,
+	req *pb.ListFolderRequest,
+) (*pb.ListFolderResponse, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := fileQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.ByFolder = true
+
+	resp := &pb.ListFolderResponse{}
+
+	if req.FolderId != "" {
+		folder, err := s.folderFor(ctx, req.FolderId, access.List)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Folder = folderToProto(folder)
+		query.FolderID = folder.UUID
+		query.CourseID = folder.CourseID
+		query.GroupID = folder.GroupID
+	} else {
+		if err := checkScope(req.CourseId, req.GroupId); err != nil {
+			return nil, err
+		}
+
+		res := access.Resource{CourseID: req.CourseId, GroupID: req.GroupId}
+
+		// Без курса и группы это личный корень вызывающего
+		if res.CourseID == "" && res.GroupID == "" {
+			res.UploaderID = userID.String()
+			query.UploaderID = userID.String()
+		}
+
+		if err := s.access.Authorize(ctx, access.List, res); err != nil {
+			return nil, err
+		}
+
+		query.CourseID = req.CourseId
+		query.GroupID = req.GroupId
+	}
+
+	folders, err := s.store.Folders().ListChildren(query.FolderID, query.CourseID, query.GroupID, userID.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	files, total, err := s.store.Meta().List(query)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	resp.Folders = foldersToProto(folders)
+	resp.Files = filesToProto(files)
+	resp.Total = int32(total)
+
+	return resp, nil
+}
+
+// folderName проверяет имя папки
+func folderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || len(name) > maxFolderNameLength || strings.ContainsAny(name, "/\\") {
+		return "", status.Errorf(codes.InvalidArgument, "folder name must be 1-%d characters without slashes", maxFolderNameLength)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,212 @@
+package public
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxTagLength       = 50
+	maxTagsPerFile     = 32
+	maxMetadataKey     = 64
+	maxMetadataValue   = 1024
+	maxMetadataPerFile = 32
+)
+
+func (s *PublicServer) AddFileTags(
+	ctx context.Context,
+	req *pb.AddFileTagsRequest,
+) (*pb.File, error) {
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tags are required")
+	}
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(file.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(file.Tags, tag) {
+			total++
+		}
+	}
+	if total > maxTagsPerFile {
+		return nil, status.Errorf(codes.FailedPrecondition, "file can have at most %d tags", maxTagsPerFile)
+	}
+
+	if err := s.store.Meta().AddTags(file.UUID, tags); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	return s.labeledFile(file.UUID)
+}
+
+func (s *PublicServer) RemoveFileTags(
+	ctx context.Context,
+	req *pb.RemoveFileTagsRequest,
+) (*pb.File, error) {
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tags are required")
+	}
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Meta().RemoveTags(file.UUID, tags); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	return s.labeledFile(file.UUID)
+}
+
+// SetFileMetadata сливает пары с существующими, пустое значение удаляет
+// ключ, а replace заменяет метаданные целиком
+func (s *PublicServer) SetFileMetadata(
+	ctx context.Context,
+	req *pb.SetFileMetadataRequest,
+) (*pb.File, error) {
+
+	if err := checkMetadata(req.Metadata, true); err != nil {
+		return nil, err
+	}
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(file.Metadata)+len(req.Metadata))
+	if !req.Replace {
+		for key := range file.Metadata {
+			keys[key] = true
+		}
+	}
+	for key, value := range req.Metadata {
+		keys[key] = value != ""
+	}
+
+	total := 0
+	for _, kept := range keys {
+		if kept {
+			total++
+		}
+	}
+	if total > maxMetadataPerFile {
+		return nil, status.Errorf(codes.FailedPrecondition, "file can have at most %d metadata keys", maxMetadataPerFile)
+	}
+
+	if err := s.store.Meta().SetMetadata(file.UUID, req.Metadata, req.Replace); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	return s.labeledFile(file.UUID)
+}
+
+func (s *PublicServer) labeledFile(fileID string) (*pb.File, error) {
+	file, err := s.store.Meta().GetByID(fileID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return fileToProto(file), nil
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает повторы
+// и проверяет допустимые символы: буквы, цифры, '-', '_' и '.'
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || len([]rune(tag)) > maxTagLength || !validLabel(tag, true) {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"tag %q must be 1-%d letters, digits, '-', '_' or '.'", tag, maxTagLength)
+		}
+
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > maxTagsPerFile {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d tags are allowed", maxTagsPerFile)
+	}
+	return normalized, nil
+}
+
+// checkMetadata проверяет ключи и значения; allowEmpty разрешает пустые
+// значения, которыми SetFileMetadata удаляет ключи
+func checkMetadata(metadata map[string]string, allowEmpty bool) error {
+	if len(metadata) > maxMetadataPerFile {
+		return status.Errorf(codes.InvalidArgument, "at most %d metadata keys are allowed", maxMetadataPerFile)
+	}
+
+	for key, value := range metadata {
+		if key == "" || len(key) > maxMetadataKey || !validLabel(key, false) {
+			return status.Errorf(codes.InvalidArgument,
+				"metadata key %q must be 1-%d latin letters, digits, '-', '_' or '.'", key, maxMetadataKey)
+		}
+		if len(value) > maxMetadataValue {
+			return status.Errorf(codes.InvalidArgument,
+				"metadata value of %q must be at most %d bytes", key, maxMetadataValue)
+		}
+		if value == "" && !allowEmpty {
+			return status.Errorf(codes.InvalidArgument, "metadata value of %q is empty", key)
+		}
+	}
+	return nil
+}
+
+// validLabel разрешает цифры, '-', '_', '.' и латинские буквы,
+// а при anyLetter - буквы любого алфавита
+func validLabel(s string, anyLetter bool) bool {
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		case anyLetter && unicode.IsLetter(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// fileFilters переводит фильтры по тегам и метаданным из запроса списка
+func fileFilters(query *model.FileQuery, req listRequest) error {
+	tags, err := normalizeTags(req.GetTags())
+	if err != nil {
+		return err
+	}
+
+	if err := checkMetadata(req.GetMetadata(), false); err != nil {
+		return err
+	}
+
+	query.Tags = tags
+	query.Metadata = req.GetMetadata()
+	return nil
+}
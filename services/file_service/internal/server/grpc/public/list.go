@@ -0,0 +1,111 @@
+package public
+
+import (
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// listRequest - общие параметры ListFilesBy* запросов
+type listRequest interface {
+	GetPage() int32
+	GetPageSize() int32
+	GetSortBy() pb.FileSortField
+	GetDesc() bool
+	GetMimeType() string
+	GetUploaderId() string
+	GetCreatedFrom() *timestamppb.Timestamp
+	GetCreatedTo() *timestamppb.Timestamp
+	GetTags() []string
+	GetMetadata() map[string]string
+}
+
+// fileQuery переводит параметры запроса в выборку, проверяя границы страницы
+func fileQuery(req listRequest) (model.FileQuery, error) {
+	offset, limit, err := pageBounds(req.GetPage(), req.GetPageSize())
+	if err != nil {
+		return model.FileQuery{}, err
+	}
+
+	query := model.FileQuery{
+		MimeType: req.GetMimeType(),
+		Desc:     req.GetDesc(),
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	switch req.GetSortBy() {
+	case pb.FileSortField_FILE_SORT_FIELD_CREATED_AT:
+		query.SortBy = model.SortByCreatedAt
+	case pb.FileSortField_FILE_SORT_FIELD_NAME:
+		query.SortBy = model.SortByName
+	case pb.FileSortField_FILE_SORT_FIELD_SIZE:
+		query.SortBy = model.SortBySize
+	default:
+		return model.FileQuery{}, status.Error(codes.InvalidArgument, "unknown sort field")
+	}
+
+	if req.GetUploaderId() != "" {
+		uploaderID, err := uuid.Parse(req.GetUploaderId())
+		if err != nil {
+			return model.FileQuery{}, status.Error(codes.InvalidArgument, "invalid uploader id")
+		}
+		query.UploaderID = uploaderID.String()
+	}
+
+	if from := req.GetCreatedFrom(); from != nil {
+		query.CreatedFrom = from.AsTime()
+	}
+	if to := req.GetCreatedTo(); to != nil {
+		query.CreatedTo = to.AsTime()
+	}
+	if !query.CreatedFrom.IsZero() && !query.CreatedTo.IsZero() && !query.CreatedFrom.Before(query.CreatedTo) {
+		return model.FileQuery{}, status.Error(codes.InvalidArgument, "created_from must be before created_to")
+	}
+
+	if err := fileFilters(&query, req); err != nil {
+		return model.FileQuery{}, err
+	}
+
+	return query, nil
+}
+
+// pageBounds переводит номер страницы с 1 и ее размер в offset и limit
+func pageBounds(page int32, size int32) (int, int, error) {
+	if page < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "page must be non-negative")
+	}
+	if page == 0 {
+		page = 1
+	}
+
+	if size < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "page size must be non-negative")
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+	limit := min(int(size), maxPageSize)
+
+	return (int(page) - 1) * limit, limit, nil
+}
+
+func (s *PublicServer) listFiles(query model.FileQuery) (*pb.ListFilesResponse, error) {
+	files, total, err := s.store.Meta().List(query)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return &pb.ListFilesResponse{
+		Files: filesToProto(files),
+		Total: int32(total),
+	}, nil
+}
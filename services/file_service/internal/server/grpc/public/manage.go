@@ -0,0 +1,260 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxFileNameLength = 255
+
+func (s *PublicServer) UpdateFileMetadata(
+	ctx context.Context,
+	req *pb.UpdateFileMetadataRequest,
+) (*pb.File, error) {
+
+	var name string
+	if req.Name != "" {
+		var err error
+		if name, err = fileName(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	// Тип не задается клиентом: он заново определяется по содержимому
+	// и новому имени, а mime_type принимается, только если совпадает с ним
+	updated := *file
+	if name != "" {
+		updated.Name = name
+		if updated.MimeType, err = s.detectObject(file.StorageKey, name); err != nil {
+			return nil, err
+		}
+	}
+	if req.MimeType != "" && !policy.SameType(req.MimeType, updated.MimeType) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"mime type %q does not match the detected type %q", req.MimeType, updated.MimeType)
+	}
+
+	// Новое имя не должно обходить политику курса
+	if err := s.checkPolicy(file.CourseID, &updated); err != nil {
+		return nil, err
+	}
+
+	var mimeType string
+	if updated.MimeType != file.MimeType {
+		mimeType = updated.MimeType
+	}
+
+	if err := s.store.Meta().UpdateMetadata(file.UUID, name, mimeType); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	file, err = s.store.Meta().GetByID(file.UUID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return fileToProto(file), nil
+}
+
+func (s *PublicServer) MoveFile(
+	ctx context.Context,
+	req *pb.MoveFileRequest,
+) (*pb.File, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err := s.resolveUpload(ctx, "", req.FolderId, req.CourseId, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPolicy(dest.courseID, file); err != nil {
+		return nil, err
+	}
+
+	// Содержимое зашифровано ключом курса, поэтому при смене курса
+	// оно перешифровывается в blob нового курса
+	var versions, rekeyed []*model.FileVersion
+	var keys map[string]string
+	if dest.courseID != file.CourseID {
+		versions, rekeyed, keys, err = s.rekeyFile(file, dest.courseID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.Meta().Move(file.UUID, dest.courseID, dest.groupID, dest.folderID, keys); err != nil {
+		s.releaseVersions(file.UUID, rekeyed)
+		return nil, writeError(err)
+	}
+	s.releaseVersions(file.UUID, versions)
+
+	file, err = s.store.Meta().GetByID(file.UUID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return fileToProto(file), nil
+}
+
+// rekeyFile перешифровывает содержимое всех версий файла в курс courseID.
+// Возвращает прежние и новые версии и новые ключи по прежним
+func (s *PublicServer) rekeyFile(
+	file *model.File,
+	courseID string,
+) (versions []*model.FileVersion, rekeyed []*model.FileVersion, keys map[string]string, err error) {
+
+	versions, err = s.store.Meta().ListVersions(file.UUID)
+	if err != nil {
+		return nil, nil, nil, status.Error(codes.Internal, "db error")
+	}
+	// У файлов без версий ссылку держит сама строка файла
+	if len(versions) == 0 {
+		versions = append(versions, fileContent(file))
+	}
+
+	keys = make(map[string]string, len(versions))
+	for _, v := range versions {
+		key, err := s.relinkContent(courseID, v)
+		if err != nil {
+			s.releaseVersions(file.UUID, rekeyed)
+			return nil, nil, nil, err
+		}
+
+		keys[v.StorageKey] = key
+		rekeyed = append(rekeyed, &model.FileVersion{Version: v.Version, Hash: v.Hash, StorageKey: key})
+	}
+
+	return versions, rekeyed, keys, nil
+}
+
+// fileContent - содержимое текущей версии файла
+func fileContent(file *model.File) *model.FileVersion {
+	return &model.FileVersion{
+		FileUUID:   file.UUID,
+		Version:    file.CurrentVersion,
+		MimeType:   file.MimeType,
+		Size:       file.Size,
+		Hash:       file.Hash,
+		CRC32C:     file.CRC32C,
+		StorageKey: file.StorageKey,
+	}
+}
+
+// CopyFile копирует текущую версию файла. Содержимое с хешем не копируется,
+// копия ссылается на тот же blob; объекты, загруженные до дедупликации,
+// копируются внутри MinIO. В другой курс содержимое перешифровывается
+func (s *PublicServer) CopyFile(
+	ctx context.Context,
+	req *pb.CopyFileRequest,
+) (*pb.File, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := s.fileFor(ctx, req.FileId, access.Download)
+	if err != nil {
+		return nil, err
+	}
+
+	name := src.Name
+	if req.Name != "" {
+		if name, err = fileName(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	dest, err := s.resolveUpload(ctx, "", req.FolderId, req.CourseId, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.policy.Check(dest.courseID, policy.Upload{
+		Filename: name,
+		MimeType: src.MimeType,
+		Size:     src.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(userID.String(), dest, src.Size); err != nil {
+		return nil, err
+	}
+
+	fileID := uuid.New()
+	storageKey := src.StorageKey
+
+	if src.Hash == "" && src.CourseID == dest.courseID {
+		storageKey = fmt.Sprintf("files/%s", fileID)
+
+		if err := s.store.File().Copy(src.StorageKey, storageKey); err != nil {
+			return nil, status.Errorf(codes.Internal, "minio copy failed: %v", err)
+		}
+	} else {
+		storageKey, err = s.relinkContent(dest.courseID, fileContent(src))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file := &model.File{
+		UUID:       fileID.String(),
+		Name:       name,
+		MimeType:   src.MimeType,
+		Size:       src.Size,
+		UploaderID: userID.String(),
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		Hash:       src.Hash,
+		CRC32C:     src.CRC32C,
+		StorageKey: storageKey,
+		CreatedAt:  time.Now(),
+		// Содержимое то же, повторная проверка и миниатюра не нужны
+		ScanResult:   src.ScanResult,
+		PreviewState: src.PreviewState,
+		TextState:    src.TextState,
+
+		Tags:     src.Tags,
+		Metadata: src.Metadata,
+	}
+
+	if err := s.saveFile(file); err != nil {
+		return nil, err
+	}
+
+	return fileToProto(file), nil
+}
+
+// fileName проверяет новое имя файла
+func fileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || len(name) > maxFileNameLength || strings.ContainsAny(name, "/\\") {
+		return "", status.Errorf(codes.InvalidArgument, "file name must be 1-%d characters without slashes", maxFileNameLength)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,55 @@
+package public
+
+import (
+	"io"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// detectObject определяет тип уже загруженного объекта по первым байтам
+func (s *PublicServer) detectObject(key string, filename string) (string, error) {
+	reader, err := s.store.File().Get(key)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "storage error: %v", err)
+	}
+	defer reader.Close()
+
+	head, err := io.ReadAll(io.LimitReader(reader, policy.SniffLen))
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "read failed: %v", err)
+	}
+
+	return policy.Detect(head, filename), nil
+}
+
+// checkPolicy проверяет файл по политике курса, в который он попадает
+func (s *PublicServer) checkPolicy(courseID string, file *model.File) error {
+	return s.policy.Check(courseID, policy.Upload{
+		Filename: file.Name,
+		MimeType: file.MimeType,
+		Size:     file.Size,
+	})
+}
+
+// admitBlob определяет тип уже хранящегося содержимого и проверяет файл
+// по политике курса, при отказе отпуская ссылку на blob
+func (s *PublicServer) admitBlob(courseID string, file *model.File, blob *model.Blob) error {
+	mimeType, err := s.detectObject(blob.StorageKey, file.Name)
+	if err == nil {
+		file.MimeType = mimeType
+		file.Size = blob.Size
+		err = s.checkPolicy(courseID, file)
+	}
+
+	if err != nil {
+		if unlinkErr := s.releaseContent(blob.Hash, blob.StorageKey); unlinkErr != nil {
+			s.logger.Warnf("failed to release blob %s: %s", blob.Hash, unlinkErr)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *PublicServer) CreateUploadURL(
+	ctx context.Context,
+	req *pb.CreateUploadURLRequest,
+) (*pb.CreateUploadURLResponse, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := req.Metadata
+	if meta == nil || meta.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	if req.Size <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size must be positive")
+	}
+
+	dest, err := s.resolveUpload(ctx, meta.FileId, meta.FolderId, meta.CourseId, meta.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := parseChecksum(meta.Sha256, meta.Crc32C)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.policy.Check(dest.courseID, policy.Upload{
+		Filename: meta.Filename,
+		Size:     req.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(userID.String(), dest, req.Size); err != nil {
+		return nil, err
+	}
+
+	uploadID := uuid.New()
+	storageKey := fmt.Sprintf("files/%s", uploadID)
+
+	url, headers, err := s.store.Presign().PresignPut(storageKey, meta.MimeType, s.cfg.PresignTTL)
+	if err != nil {
+		return nil, presignError(err)
+	}
+
+	// Загрузка ждет ConfirmUpload в виде сессии, иначе объект удалит session gc
+	session := &model.UploadSession{
+		UUID:       uploadID.String(),
+		UploaderID: userID.String(),
+		Filename:   meta.Filename,
+		MimeType:   meta.MimeType,
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		FileID:     dest.targetID,
+		Size:       req.Size,
+		SHA256:     expected.SHA256,
+		CRC32C:     expected.CRC32C,
+		StorageKey: storageKey,
+		Presigned:  true,
+		ExpiresAt:  time.Now().Add(s.cfg.SessionTTL),
+	}
+
+	if err := s.store.Sessions().Create(session); err != nil {
+		return nil, status.Errorf(codes.Internal, "db insert failed: %v", err)
+	}
+
+	h := make(map[string]string, len(headers))
+	for k := range headers {
+		h[k] = headers.Get(k)
+	}
+
+	return &pb.CreateUploadURLResponse{
+		UploadId:  uploadID.String(),
+		Url:       url,
+		Headers:   h,
+		ExpiresAt: timestamppb.New(time.Now().Add(s.cfg.PresignTTL)),
+	}, nil
+}
+
+func (s *PublicServer) ConfirmUpload(
+	ctx context.Context,
+	req *pb.ConfirmUploadRequest,
+) (*pb.UploadFileResponse, error) {
+
+	session, err := s.ownSession(ctx, req.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.Presigned {
+		return nil, status.Error(codes.FailedPrecondition, "upload is not a presigned upload")
+	}
+
+	// Presigned PUT может перезаписать объект и после проверки, поэтому
+	// размер, тип и суммы проверяются на копии, недоступной клиенту
+	key := fmt.Sprintf("files/%s", uuid.New())
+	err = s.store.File().Copy(session.StorageKey, key)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "object has not been uploaded yet")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "minio copy failed: %v", err)
+	}
+
+	info, err := s.store.File().Stat(key)
+	if err != nil {
+		s.dropObject(key)
+		return nil, status.Errorf(codes.Internal, "storage error: %v", err)
+	}
+
+	if info.Size != session.Size || (session.MimeType != "" && info.ContentType != session.MimeType) {
+		s.dropObject(key)
+		s.dropObject(session.StorageKey)
+		_ = s.store.Sessions().Delete(session.UUID)
+
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"uploaded object does not match: size %d (expected %d), content type %q (expected %q)",
+			info.Size, session.Size, info.ContentType, session.MimeType,
+		)
+	}
+
+	mimeType, err := s.detectObject(key, session.Filename)
+	if err != nil {
+		s.dropObject(key)
+		return nil, err
+	}
+
+	err = s.policy.Check(session.CourseID, policy.Upload{
+		Filename: session.Filename,
+		MimeType: mimeType,
+		Size:     info.Size,
+	})
+	if err != nil {
+		s.dropObject(key)
+		s.dropObject(session.StorageKey)
+		_ = s.store.Sessions().Delete(session.UUID)
+		return nil, err
+	}
+
+	sum, err := s.sumObject(key)
+	if err != nil {
+		s.dropObject(key)
+		return nil, err
+	}
+
+	expected := checksum.Sum{SHA256: session.SHA256, CRC32C: session.CRC32C}
+	if err := verifyChecksum(expected, sum); err != nil {
+		s.dropObject(key)
+		s.dropObject(session.StorageKey)
+		_ = s.store.Sessions().Delete(session.UUID)
+		return nil, err
+	}
+
+	blob, err := s.linkBlob(session.CourseID, key, sum, info.Size)
+	if err != nil {
+		return nil, err
+	}
+	s.dropObject(session.StorageKey)
+
+	file := &model.File{
+		UUID:       session.UUID,
+		Name:       session.Filename,
+		MimeType:   mimeType,
+		Size:       blob.Size,
+		UploaderID: session.UploaderID,
+		CourseID:   session.CourseID,
+		GroupID:    session.GroupID,
+		FolderID:   session.FolderID,
+		Hash:       blob.Hash,
+		CRC32C:     blob.CRC32C,
+		StorageKey: blob.StorageKey,
+		CreatedAt:  time.Now(),
+	}
+
+	file, err = s.commitFile(session.FileID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Sessions().Delete(session.UUID); err != nil {
+		s.logger.Warnf("failed to delete confirmed upload %s: %s", session.UUID, err)
+	}
+
+	return &pb.UploadFileResponse{
+		FileId:  file.UUID,
+		Size:    file.Size,
+		Version: file.CurrentVersion,
+	}, nil
+}
+
+func (s *PublicServer) CreateDownloadURL(
+	ctx context.Context,
+	req *pb.CreateDownloadURLRequest,
+) (*pb.CreateDownloadURLResponse, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Download)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkContent(file); err != nil {
+		return nil, err
+	}
+
+	url, err := s.store.Presign().PresignGet(
+		file.StorageKey,
+		utils.ContentDisposition(file.Name, req.Inline),
+		s.cfg.PresignTTL,
+	)
+	if err != nil {
+		return nil, presignError(err)
+	}
+
+	return &pb.CreateDownloadURLResponse{
+		Url:       url,
+		ExpiresAt: timestamppb.New(time.Now().Add(s.cfg.PresignTTL)),
+	}, nil
+}
+
+// presignError переводит ошибку выпуска ссылки в статус
+func presignError(err error) error {
+	if errors.Is(err, store.ErrNotSupported) {
+		return status.Error(codes.Unimplemented, "storage driver does not support direct links, transfer content through the service")
+	}
+	return status.Errorf(codes.Internal, "presign failed: %v", err)
+}
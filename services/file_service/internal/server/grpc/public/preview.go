@@ -0,0 +1,74 @@
+package public
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/preview"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *PublicServer) GetFilePreview(
+	ctx context.Context,
+	req *pb.GetFilePreviewRequest,
+) (*pb.GetFilePreviewResponse, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Download)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkContent(file); err != nil {
+		return nil, err
+	}
+
+	switch file.PreviewState {
+	case model.PreviewReady:
+	case model.PreviewPending:
+		return nil, status.Error(codes.FailedPrecondition, "preview is not generated yet")
+	default:
+		return nil, status.Error(codes.NotFound, "file has no preview")
+	}
+
+	key := preview.Key(file.StorageKey)
+
+	if req.Content {
+		reader, err := s.store.File().Get(key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "storage error: %v", err)
+		}
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "read failed: %v", err)
+		}
+
+		return &pb.GetFilePreviewResponse{
+			MimeType: preview.MimeType,
+			Content:  content,
+		}, nil
+	}
+
+	name := strings.TrimSuffix(file.Name, path.Ext(file.Name)) + ".jpg"
+
+	url, err := s.store.Presign().PresignGet(key, utils.ContentDisposition(name, true), s.cfg.PresignTTL)
+	if err != nil {
+		return nil, presignError(err)
+	}
+
+	return &pb.GetFilePreviewResponse{
+		MimeType:  preview.MimeType,
+		Url:       url,
+		ExpiresAt: timestamppb.New(time.Now().Add(s.cfg.PresignTTL)),
+	}, nil
+}
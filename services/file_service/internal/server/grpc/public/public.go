@@ -1,6 +1,7 @@
 package public
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -8,14 +9,182 @@ import (
 	"time"
 
 	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+func (s *PublicServer) UploadFile(stream pb.FileService_UploadFileServer) error {
+	ctx := stream.Context()
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Первое сообщение - метаданные файла
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive metadata: %v", err)
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	if meta.Filename == "" {
+		return status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	if meta.Size < 0 {
+		return status.Error(codes.InvalidArgument, "size must be non-negative")
+	}
+
+	dest, err := s.resolveUpload(ctx, meta.FileId, meta.FolderId, meta.CourseId, meta.GroupId)
+	if err != nil {
+		return err
+	}
+
+	// Заявленный размер отсекает загрузку сверх квоты до записи в хранилище,
+	// без него отклоняются только уже исчерпавшие квоту
+	quotaLeft, err := s.quotaLeft(userID.String(), dest, meta.Size)
+	if err != nil {
+		return err
+	}
+
+	expected, err := parseChecksum(meta.Sha256, meta.Crc32C)
+	if err != nil {
+		return err
+	}
+
+	fileID := uuid.New()
+	reader := newUploadReader(stream)
+
+	// Заявленный клиентом mime_type не используется, тип определяется
+	// по содержимому
+	file := &model.File{
+		UUID:       fileID.String(),
+		Name:       meta.Filename,
+		UploaderID: userID.String(),
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		CreatedAt:  time.Now(),
+	}
+
+	var blob *model.Blob
+
+	// Известное содержимое привязывается без передачи чанков
+	if expected.SHA256 != "" && reader.Empty() {
+		blob, err = s.linkExisting(dest.courseID, expected)
+		if err != nil {
+			return err
+		}
+
+		if err := s.admitBlob(dest.courseID, file, blob); err != nil {
+			return err
+		}
+
+		if meta.Size > 0 && blob.Size != meta.Size {
+			if releaseErr := s.releaseContent(blob.Hash, blob.StorageKey); releaseErr != nil {
+				s.logger.Warnf("failed to release blob %s: %s", blob.Hash, releaseErr)
+			}
+			return status.Errorf(codes.InvalidArgument, "content has %d bytes, but size %d was declared", blob.Size, meta.Size)
+		}
+	} else {
+		// Тип и запреты проверяются по первым байтам до записи в MinIO
+		head := bufio.NewReaderSize(reader, policy.SniffLen)
+		sniffed, _ := head.Peek(policy.SniffLen)
+		if streamErr := reader.Err(); streamErr != nil {
+			return status.Errorf(codes.Aborted, "upload stream failed: %v", streamErr)
+		}
+
+		file.MimeType = policy.Detect(sniffed, file.Name)
+		if err := s.checkPolicy(dest.courseID, file); err != nil {
+			return err
+		}
+
+		// В хранилище пишется не больше наименьшего из лимита политики,
+		// остатка квоты и заявленного размера. Лишний байт сверх него
+		// отличает превышение от файла ровно в лимит
+		limit := quotaLeft
+		if maxSize := s.policy.MaxSize(dest.courseID); maxSize > 0 && (limit < 0 || maxSize < limit) {
+			limit = maxSize
+		}
+		if meta.Size > 0 && (limit < 0 || meta.Size < limit) {
+			limit = meta.Size
+		}
+
+		var body io.Reader = head
+		if limit >= 0 {
+			body = io.LimitReader(head, limit+1)
+		}
+
+		// Чанки идут в MinIO напрямую, размер заранее неизвестен,
+		// поэтому объект кладется во временный ключ до подсчета хеша
+		stagingKey := fmt.Sprintf("files/%s", fileID)
+		hasher := checksum.New()
+
+		err = s.store.CourseFile(dest.courseID).Put(stagingKey, io.TeeReader(body, hasher), -1, file.MimeType)
+		if streamErr := reader.Err(); streamErr != nil {
+			s.dropObject(stagingKey)
+			return status.Errorf(codes.Aborted, "upload stream failed: %v", streamErr)
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "minio upload failed: %v", err)
+		}
+
+		file.Size = reader.Size()
+		if err := s.checkPolicy(dest.courseID, file); err != nil {
+			s.dropObject(stagingKey)
+			return err
+		}
+
+		if meta.Size > 0 && file.Size != meta.Size {
+			s.dropObject(stagingKey)
+			return status.Errorf(codes.InvalidArgument, "uploaded %d bytes, but size %d was declared", file.Size, meta.Size)
+		}
+
+		if quotaLeft >= 0 && file.Size > quotaLeft {
+			s.dropObject(stagingKey)
+			return status.Error(codes.ResourceExhausted, "storage quota exceeded")
+		}
+
+		sum := hasher.Sum()
+		if err := verifyChecksum(expected, sum); err != nil {
+			s.dropObject(stagingKey)
+			return err
+		}
+
+		blob, err = s.linkBlob(dest.courseID, stagingKey, sum, reader.Size())
+		if err != nil {
+			return err
+		}
+	}
+
+	file.Size = blob.Size
+	file.Hash = blob.Hash
+	file.CRC32C = blob.CRC32C
+	file.StorageKey = blob.StorageKey
+
+	file, err = s.commitFile(dest.targetID, file)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&pb.UploadFileResponse{
+		FileId:  file.UUID,
+		Size:    file.Size,
+		Version: file.CurrentVersion,
+	})
+}
+
 func (s *PublicServer) UploadFileUnary(
 	ctx context.Context,
 	req *pb.UploadFileUnaryRequest,
@@ -26,16 +195,47 @@ func (s *PublicServer) UploadFileUnary(
 		return nil, err
 	}
 
-	fileID := uuid.New()
-	storageKey := fmt.Sprintf("files/%s", fileID)
+	dest, err := s.resolveUpload(ctx, req.FileId, req.FolderId, req.CourseId, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
 
-	// Загружаем в MinIO
-	err = s.store.File().Put(
-		storageKey,
-		bytes.NewReader(req.Content),
-		int64(len(req.Content)),
-		req.MimeType,
-	)
+	expected, err := parseChecksum(req.Sha256, req.Crc32C)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := policy.Detect(req.Content, req.Filename)
+
+	err = s.policy.Check(dest.courseID, policy.Upload{
+		Filename: req.Filename,
+		MimeType: mimeType,
+		Size:     int64(len(req.Content)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(userID.String(), dest, int64(len(req.Content))); err != nil {
+		return nil, err
+	}
+
+	fileID := uuid.New()
+	sum := checksum.Of(req.Content)
+	if err := verifyChecksum(expected, sum); err != nil {
+		return nil, err
+	}
+	contentKey := blobKey(dest.courseID, sum.SHA256)
+
+	// Загружаем в MinIO, только если такого содержимого еще нет
+	blob, err := s.store.Blobs().Link(sum.SHA256, sum.CRC32C, contentKey, int64(len(req.Content)), func(key string) error {
+		return s.store.CourseFile(dest.courseID).Put(
+			key,
+			bytes.NewReader(req.Content),
+			int64(len(req.Content)),
+			mimeType,
+		)
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "minio upload failed: %v", err)
 	}
@@ -44,39 +244,111 @@ func (s *PublicServer) UploadFileUnary(
 	file := &model.File{
 		UUID:       fileID.String(),
 		Name:       req.Filename,
-		MimeType:   req.MimeType,
-		Size:       int64(len(req.Content)),
+		MimeType:   mimeType,
+		Size:       blob.Size,
 		UploaderID: userID.String(),
-		CourseID:   req.CourseId,
-		GroupID:    req.GroupId,
-		StorageKey: storageKey,
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		Hash:       blob.Hash,
+		CRC32C:     blob.CRC32C,
+		StorageKey: blob.StorageKey,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := s.store.Meta().Create(file); err != nil {
-		// rollback MinIO
-		_ = s.store.File().Delete(storageKey)
-		return nil, status.Errorf(codes.Internal, "db insert failed: %v", err)
+	file, err = s.commitFile(dest.targetID, file)
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.UploadFileUnaryResponse{
-		FileId: fileID.String(),
+		FileId:  file.UUID,
+		Version: file.CurrentVersion,
 	}, nil
 }
 
+func (s *PublicServer) DownloadFile(
+	req *pb.DownloadFileRequest,
+	stream pb.FileService_DownloadFileServer,
+) error {
+
+	if req.Offset < 0 || req.Length < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must be non-negative")
+	}
+
+	file, err := s.fileFor(stream.Context(), req.FileId, access.Download)
+	if err != nil {
+		return err
+	}
+
+	return s.sendFile(stream, file, req.Offset, req.Length)
+}
+
+// sendFile отправляет заголовок и length байт содержимого file начиная с offset
+func (s *PublicServer) sendFile(
+	stream pb.FileService_DownloadFileServer,
+	file *model.File,
+	offset int64,
+	length int64,
+) error {
+
+	if err := checkContent(file); err != nil {
+		return err
+	}
+
+	if offset > file.Size {
+		return status.Errorf(codes.OutOfRange, "offset %d is beyond file size %d", offset, file.Size)
+	}
+
+	rest := file.Size - offset
+	if length <= 0 || length > rest {
+		length = rest
+	}
+
+	info, err := s.store.File().Stat(file.StorageKey)
+	if err != nil {
+		return status.Errorf(codes.Internal, "storage error: %v", err)
+	}
+
+	err = stream.Send(&pb.DownloadFileResponse{
+		Data: &pb.DownloadFileResponse_Header{
+			Header: &pb.DownloadFileHeader{
+				File:   fileToProto(file),
+				Etag:   info.ETag,
+				Offset: offset,
+				Length: length,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if length == 0 {
+		return nil
+	}
+
+	reader, err := s.store.File().GetRange(file.StorageKey, offset, length)
+	if err != nil {
+		return status.Errorf(codes.Internal, "storage error: %v", err)
+	}
+	defer reader.Close()
+
+	return sendChunks(stream, reader)
+}
+
 func (s *PublicServer) DownloadFileUnary(
 	ctx context.Context,
 	req *pb.DownloadFileUnaryRequest,
 ) (*pb.DownloadFileUnaryResponse, error) {
 
-	fileID, err := uuid.Parse(req.FileId)
+	file, err := s.fileFor(ctx, req.FileId, access.Download)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid file id")
+		return nil, err
 	}
 
-	file, err := s.store.Meta().GetByID(fileID.String())
-	if err != nil {
-		return nil, status.Error(codes.NotFound, "file not found")
+	if err := checkContent(file); err != nil {
+		return nil, err
 	}
 
 	reader, err := s.store.File().Get(file.StorageKey)
@@ -102,47 +374,52 @@ func (s *PublicServer) GetFile(
 	req *pb.GetFileRequest,
 ) (*pb.File, error) {
 
-	fileID, err := uuid.Parse(req.FileId)
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid id")
-	}
-
-	file, err := s.store.Meta().GetByID(fileID.String())
+	file, err := s.fileFor(ctx, req.FileId, access.Get)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "not found")
+		return nil, err
 	}
 
-	return &pb.File{
-		Id:         file.UUID,
-		Name:       file.Name,
-		MimeType:   file.MimeType,
-		Size:       file.Size,
-		UploaderId: file.UploaderID,
-		CourseId:   file.CourseID,
-		GroupId:    file.GroupID,
-		CreatedAt:  timestamppb.New(file.CreatedAt),
-	}, nil
+	return fileToProto(file), nil
 }
 
 func (s *PublicServer) DeleteFile(
 	ctx context.Context,
 	req *pb.DeleteFileRequest,
 ) (*pb.DeleteFileResponse, error) {
-	fileID, err := uuid.Parse(req.FileId)
+	file, err := s.fileFor(ctx, req.FileId, access.Delete)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid id")
+		return nil, err
+	}
+
+	// Файл уходит в корзину, содержимое удаляется при очистке
+	if err := s.store.Meta().Delete(file.UUID); err != nil {
+		return nil, status.Error(codes.Internal, "db error")
 	}
 
-	file, err := s.store.Meta().GetByID(fileID.String())
+	return &pb.DeleteFileResponse{Success: true}, nil
+}
+
+func (s *PublicServer) ListFilesByUser(
+	ctx context.Context,
+	req *pb.ListFilesByUserRequest,
+) (*pb.ListFilesResponse, error) {
+
+	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "file not found")
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
 	}
 
-	if err := s.store.File().Delete(file.StorageKey); err != nil {
-		return nil, status.Error(codes.Internal, "storage error")
+	if err := s.access.Authorize(ctx, access.List, access.Resource{UploaderID: userID.String()}); err != nil {
+		return nil, err
 	}
 
-	return &pb.DeleteFileResponse{Success: true}, nil
+	query, err := fileQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.UploaderID = userID.String()
+
+	return s.listFiles(query)
 }
 
 func (s *PublicServer) ListFilesByCourse(
@@ -150,35 +427,22 @@ func (s *PublicServer) ListFilesByCourse(
 	req *pb.ListFilesByCourseRequest,
 ) (*pb.ListFilesResponse, error) {
 
-	courseID, err := uuid.Parse(req.CourseId)
+	courseID, err := scopeID(req.CourseId, "course")
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid course id")
+		return nil, err
 	}
 
-	files, err := s.store.Meta().ListByCourse(courseID.String())
-	if err != nil {
-		return nil, status.Error(codes.Internal, "db error")
+	if err := s.access.Authorize(ctx, access.List, access.Resource{CourseID: courseID}); err != nil {
+		return nil, err
 	}
 
-	f := make([]*pb.File, 0)
-	for _, file := range files {
-		pf := &pb.File{
-			Id:         file.UUID,
-			Name:       file.Name,
-			MimeType:   file.MimeType,
-			Size:       file.Size,
-			UploaderId: file.UploaderID,
-			CourseId:   file.CourseID,
-			GroupId:    file.GroupID,
-			CreatedAt:  timestamppb.New(file.CreatedAt),
-		}
-		f = append(f, pf)
+	query, err := fileQuery(req)
+	if err != nil {
+		return nil, err
 	}
+	query.CourseID = courseID
 
-	return &pb.ListFilesResponse{
-		Files: f,
-		Total: int32(len(f)),
-	}, nil
+	return s.listFiles(query)
 }
 
 func (s *PublicServer) ListFilesByGroup(
@@ -186,33 +450,20 @@ func (s *PublicServer) ListFilesByGroup(
 	req *pb.ListFilesByGroupRequest,
 ) (*pb.ListFilesResponse, error) {
 
-	groupID, err := uuid.Parse(req.GroupId)
+	groupID, err := scopeID(req.GroupId, "group")
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid group id")
+		return nil, err
 	}
 
-	files, err := s.store.Meta().ListByGroup(groupID.String())
-	if err != nil {
-		return nil, status.Error(codes.Internal, "db error")
+	if err := s.access.Authorize(ctx, access.List, access.Resource{GroupID: groupID}); err != nil {
+		return nil, err
 	}
 
-	f := make([]*pb.File, 0)
-	for _, file := range files {
-		pf := &pb.File{
-			Id:         file.UUID,
-			Name:       file.Name,
-			MimeType:   file.MimeType,
-			Size:       file.Size,
-			UploaderId: file.UploaderID,
-			CourseId:   file.CourseID,
-			GroupId:    file.GroupID,
-			CreatedAt:  timestamppb.New(file.CreatedAt),
-		}
-		f = append(f, pf)
+	query, err := fileQuery(req)
+	if err != nil {
+		return nil, err
 	}
+	query.GroupID = groupID
 
-	return &pb.ListFilesResponse{
-		Files: f,
-		Total: int32(len(f)),
-	}, nil
+	return s.listFiles(query)
 }
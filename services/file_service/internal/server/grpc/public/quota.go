@@ -0,0 +1,112 @@
+package public
+
+import (
+	"context"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *PublicServer) GetUsage(
+	ctx context.Context,
+	req *pb.GetUsageRequest,
+) (*pb.GetUsageResponse, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId != "" {
+		if userID, err = uuid.Parse(req.UserId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		}
+	}
+
+	// Чужой объем виден только администратору
+	if err := s.access.Authorize(ctx, access.Get, access.Resource{UploaderID: userID.String()}); err != nil {
+		return nil, err
+	}
+
+	usage, err := s.store.Meta().Usage(model.UsageScopeUser, userID.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	resp := &pb.GetUsageResponse{User: usageToProto(usage)}
+
+	if req.CourseId != "" {
+		courseID, err := scopeID(req.CourseId, "course")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.access.Authorize(ctx, access.List, access.Resource{CourseID: courseID}); err != nil {
+			return nil, err
+		}
+
+		usage, err := s.store.Meta().Usage(model.UsageScopeCourse, courseID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "db error")
+		}
+		resp.Course = usageToProto(usage)
+	}
+
+	return resp, nil
+}
+
+// checkQuota заранее отклоняет загрузку известного размера, которая не
+// поместится в лимиты. Окончательная проверка идет при сохранении файла
+func (s *PublicServer) checkQuota(uploaderID string, dest uploadDest, size int64) error {
+	_, err := s.quotaLeft(uploaderID, dest, size)
+	return err
+}
+
+// quotaLeft проверяет квоты как checkQuota и возвращает, сколько байт
+// в них еще помещается, -1 - квоты не ограничены
+func (s *PublicServer) quotaLeft(uploaderID string, dest uploadDest, size int64) (int64, error) {
+	usage, err := s.store.Meta().Usage(model.UsageScopeUser, uploaderID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "db error")
+	}
+	if exceeds(usage, size) {
+		return 0, status.Error(codes.ResourceExhausted, "user storage quota exceeded")
+	}
+	left := remaining(usage, -1)
+
+	if dest.courseID == "" {
+		return left, nil
+	}
+
+	usage, err = s.store.Meta().Usage(model.UsageScopeCourse, dest.courseID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "db error")
+	}
+	if exceeds(usage, size) {
+		return 0, status.Error(codes.ResourceExhausted, "course storage quota exceeded")
+	}
+
+	return remaining(usage, left), nil
+}
+
+func exceeds(usage *model.Usage, size int64) bool {
+	return usage.Limit > 0 && usage.Bytes+size > usage.Limit
+}
+
+// remaining уменьшает left до остатка квоты usage, left -1 - без ограничения
+func remaining(usage *model.Usage, left int64) int64 {
+	if usage.Limit <= 0 {
+		return left
+	}
+
+	rest := max(usage.Limit-usage.Bytes, 0)
+	if left < 0 || rest < left {
+		return rest
+	}
+	return left
+}
@@ -0,0 +1,26 @@
+package public
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkContent не дает скачать содержимое, пока сканер не признал его
+// чистым, и сообщает о содержимом, пропавшем из хранилища
+func checkContent(file *model.File) error {
+	if file.ContentMissing {
+		return status.Error(codes.DataLoss, "file content is missing from storage")
+	}
+
+	switch file.ScanStatus {
+	case model.ScanClean:
+		return nil
+	case model.ScanInfected:
+		return status.Errorf(codes.FailedPrecondition, "file is infected: %s", file.ScanSignature)
+	case model.ScanFailed:
+		return status.Error(codes.FailedPrecondition, "file could not be scanned for malware")
+	default:
+		return status.Error(codes.FailedPrecondition, "file is pending malware scan")
+	}
+}
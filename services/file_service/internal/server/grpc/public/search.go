@@ -0,0 +1,76 @@
+package public
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ограничение длины поискового запроса
+const maxSearchQueryLength = 256
+
+// SearchFiles ищет только среди файлов, которые вызывающий может получить:
+// своих и файлов курсов и групп, где он преподает или состоит
+func (s *PublicServer) SearchFiles(
+	ctx context.Context,
+	req *pb.SearchFilesRequest,
+) (*pb.SearchFilesResponse, error) {
+
+	text := strings.TrimSpace(req.Query)
+	if text == "" || len(text) > maxSearchQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "query must be 1-%d characters", maxSearchQueryLength)
+	}
+
+	offset, limit, err := pageBounds(req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	query := model.SearchQuery{
+		Text:     text,
+		MimeType: req.MimeType,
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	if err := checkScope(req.CourseId, req.GroupId); err != nil {
+		return nil, err
+	}
+	query.CourseID = req.CourseId
+	query.GroupID = req.GroupId
+
+	membership, err := s.access.Membership(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if membership.IsAdmin() {
+		query.AllAccess = true
+	} else {
+		query.UserID = membership.UserID
+		query.CourseIDs = membership.CourseIDs()
+		query.GroupIDs = membership.GroupIDs()
+	}
+
+	hits, total, err := s.store.Meta().Search(query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "search failed: %v", err)
+	}
+
+	return &pb.SearchFilesResponse{
+		Hits:  searchHitsToProto(hits),
+		Total: int32(total),
+	}, nil
+}
+
+// highlight экранирует текст и заменяет метки совпадений на <mark>
+func highlight(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, model.HighlightStart, "<mark>")
+	return strings.ReplaceAll(text, model.HighlightStop, "</mark>")
+}
@@ -3,6 +3,8 @@ package public
 import (
 	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
 	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
 )
 
@@ -11,11 +13,23 @@ type PublicServer struct {
 
 	logger logger.Logger
 	store  store.Store
+	access *access.Checker
+	policy *policy.Policy
+	cfg    Config
 }
 
-func New(logger logger.Logger, store store.Store) *PublicServer {
+func New(
+	logger logger.Logger,
+	store store.Store,
+	access *access.Checker,
+	policy *policy.Policy,
+	cfg Config,
+) *PublicServer {
 	return &PublicServer{
 		store:  store,
+		access: access,
+		policy: policy,
+		cfg:    cfg,
 		logger: logger.WithFields("layer", "grpc server api", "public"),
 	}
 }
@@ -0,0 +1,322 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Размер части multipart upload, каждый чанк кроме последнего
+// должен быть ему кратен
+const sessionPartSize = store.PartSize
+
+func (s *PublicServer) CreateUploadSession(
+	ctx context.Context,
+	req *pb.CreateUploadSessionRequest,
+) (*pb.UploadSession, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := req.Metadata
+	if meta == nil || meta.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	if req.Size <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size must be positive")
+	}
+
+	dest, err := s.resolveUpload(ctx, meta.FileId, meta.FolderId, meta.CourseId, meta.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := parseChecksum(meta.Sha256, meta.Crc32C)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.policy.Check(dest.courseID, policy.Upload{
+		Filename: meta.Filename,
+		Size:     req.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(userID.String(), dest, req.Size); err != nil {
+		return nil, err
+	}
+
+	sessionID := uuid.New()
+	storageKey := fmt.Sprintf("files/%s", sessionID)
+
+	uploadID, err := s.store.CourseMultipart(dest.courseID).NewMultipart(storageKey, meta.MimeType, req.Size)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "minio multipart init failed: %v", err)
+	}
+
+	session := &model.UploadSession{
+		UUID:       sessionID.String(),
+		UploaderID: userID.String(),
+		Filename:   meta.Filename,
+		MimeType:   meta.MimeType,
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		FileID:     dest.targetID,
+		Size:       req.Size,
+		SHA256:     expected.SHA256,
+		CRC32C:     expected.CRC32C,
+		StorageKey: storageKey,
+		UploadID:   uploadID,
+		ExpiresAt:  time.Now().Add(s.cfg.SessionTTL),
+	}
+
+	if err := s.store.Sessions().Create(session); err != nil {
+		_ = s.store.Multipart().AbortMultipart(storageKey, uploadID)
+		return nil, status.Errorf(codes.Internal, "db insert failed: %v", err)
+	}
+
+	return sessionToProto(session), nil
+}
+
+func (s *PublicServer) WriteUploadSession(stream pb.FileService_WriteUploadSessionServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive header: %v", err)
+	}
+
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain header")
+	}
+
+	session, err := s.ownSession(ctx, header.SessionId)
+	if err != nil {
+		return err
+	}
+
+	if session.Presigned {
+		return status.Error(codes.FailedPrecondition, "session is a presigned upload")
+	}
+
+	if header.Offset != session.Offset {
+		return status.Errorf(codes.FailedPrecondition, "offset mismatch: session is at %d", session.Offset)
+	}
+
+	end := header.Offset + header.Length
+	if header.Length <= 0 || end > session.Size {
+		return status.Errorf(codes.InvalidArgument, "chunk must be within %d bytes of the file", session.Size)
+	}
+
+	if end != session.Size && header.Length%sessionPartSize != 0 {
+		return status.Errorf(codes.InvalidArgument, "chunk length must be a multiple of %d except for the last one", sessionPartSize)
+	}
+
+	reader := newSessionReader(stream)
+
+	// Каждая часть фиксируется в offset сразу, чтобы обрыв
+	// не терял уже принятые части
+	for pos := header.Offset; pos < end; pos += sessionPartSize {
+		n := min(int64(sessionPartSize), end-pos)
+		partNumber := int(pos/sessionPartSize) + 1
+
+		err := s.store.Multipart().PutPart(
+			session.StorageKey,
+			session.UploadID,
+			partNumber,
+			io.LimitReader(reader, n),
+			n,
+		)
+		if streamErr := reader.Err(); streamErr != nil {
+			return status.Errorf(codes.Aborted, "upload stream failed: %v", streamErr)
+		}
+		if reader.Size() < pos+n-header.Offset {
+			return status.Error(codes.InvalidArgument, "chunk is shorter than declared length")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "minio part upload failed: %v", err)
+		}
+
+		if err := s.store.Sessions().Advance(session.UUID, pos, pos+n); err != nil {
+			if errors.Is(err, store.ErrConflict) {
+				return status.Error(codes.Aborted, "session was written concurrently")
+			}
+			return status.Errorf(codes.Internal, "db update failed: %v", err)
+		}
+		session.Offset = pos + n
+	}
+
+	if n, _ := reader.Read(make([]byte, 1)); n > 0 {
+		return status.Error(codes.InvalidArgument, "chunk is longer than declared length")
+	}
+
+	return stream.SendAndClose(sessionToProto(session))
+}
+
+func (s *PublicServer) GetUploadSession(
+	ctx context.Context,
+	req *pb.GetUploadSessionRequest,
+) (*pb.UploadSession, error) {
+
+	session, err := s.ownSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionToProto(session), nil
+}
+
+func (s *PublicServer) FinalizeUploadSession(
+	ctx context.Context,
+	req *pb.FinalizeUploadSessionRequest,
+) (*pb.UploadFileResponse, error) {
+
+	session, err := s.ownSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Presigned {
+		return nil, status.Error(codes.FailedPrecondition, "session is a presigned upload, use ConfirmUpload")
+	}
+
+	if session.Offset != session.Size {
+		return nil, status.Errorf(codes.FailedPrecondition, "upload is incomplete: %d of %d bytes", session.Offset, session.Size)
+	}
+
+	if err := s.store.Multipart().CompleteMultipart(session.StorageKey, session.UploadID); err != nil {
+		return nil, status.Errorf(codes.Internal, "minio multipart complete failed: %v", err)
+	}
+
+	mimeType, err := s.detectObject(session.StorageKey, session.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.policy.Check(session.CourseID, policy.Upload{
+		Filename: session.Filename,
+		MimeType: mimeType,
+		Size:     session.Size,
+	})
+	if err != nil {
+		s.dropObject(session.StorageKey)
+		_ = s.store.Sessions().Delete(session.UUID)
+		return nil, err
+	}
+
+	sum, err := s.sumObject(session.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := checksum.Sum{SHA256: session.SHA256, CRC32C: session.CRC32C}
+	if err := verifyChecksum(expected, sum); err != nil {
+		s.dropObject(session.StorageKey)
+		_ = s.store.Sessions().Delete(session.UUID)
+		return nil, err
+	}
+
+	blob, err := s.linkBlob(session.CourseID, session.StorageKey, sum, session.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &model.File{
+		UUID:       session.UUID,
+		Name:       session.Filename,
+		MimeType:   mimeType,
+		Size:       blob.Size,
+		UploaderID: session.UploaderID,
+		CourseID:   session.CourseID,
+		GroupID:    session.GroupID,
+		FolderID:   session.FolderID,
+		Hash:       blob.Hash,
+		CRC32C:     blob.CRC32C,
+		StorageKey: blob.StorageKey,
+		CreatedAt:  time.Now(),
+	}
+
+	file, err = s.commitFile(session.FileID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Sessions().Delete(session.UUID); err != nil {
+		s.logger.Warnf("failed to delete finalized upload session %s: %s", session.UUID, err)
+	}
+
+	return &pb.UploadFileResponse{
+		FileId:  file.UUID,
+		Size:    file.Size,
+		Version: file.CurrentVersion,
+	}, nil
+}
+
+func (s *PublicServer) AbortUploadSession(
+	ctx context.Context,
+	req *pb.AbortUploadSessionRequest,
+) (*pb.AbortUploadSessionResponse, error) {
+
+	session, err := s.ownSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Presigned {
+		err = s.store.File().Delete(session.StorageKey)
+	} else {
+		err = s.store.Multipart().AbortMultipart(session.StorageKey, session.UploadID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "minio abort failed: %v", err)
+	}
+
+	if err := s.store.Sessions().Delete(session.UUID); err != nil {
+		return nil, status.Errorf(codes.Internal, "db delete failed: %v", err)
+	}
+
+	return &pb.AbortUploadSessionResponse{Success: true}, nil
+}
+
+// ownSession возвращает живую сессию, принадлежащую вызывающему
+func (s *PublicServer) ownSession(ctx context.Context, id string) (*model.UploadSession, error) {
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session id")
+	}
+
+	session, err := s.store.Sessions().GetByID(sessionID.String())
+	if err != nil || session.ExpiresAt.Before(time.Now()) {
+		return nil, status.Error(codes.NotFound, "upload session not found")
+	}
+
+	if session.UploaderID != userID.String() {
+		return nil, status.Error(codes.PermissionDenied, "upload session belongs to another user")
+	}
+
+	return session, nil
+}
@@ -0,0 +1,243 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Делиться файлом могут те, кто может его изменять
+func (s *PublicServer) CreateShareLink(
+	ctx context.Context,
+	req *pb.CreateShareLinkRequest,
+) (*pb.ShareLink, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MaxDownloads < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max downloads must be non-negative")
+	}
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	token := utils.NewToken()
+	link := &model.ShareLink{
+		UUID:         uuid.New().String(),
+		Token:        token,
+		TokenHash:    utils.HashToken(token),
+		FileUUID:     file.UUID,
+		MaxDownloads: req.MaxDownloads,
+		CreatorID:    userID.String(),
+	}
+
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		if !expiresAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		link.ExpiresAt = &expiresAt
+	}
+
+	if req.Password != "" {
+		link.PasswordHash, err = utils.HashPassword(req.Password)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "password hashing failed: %v", err)
+		}
+	}
+
+	if err := s.store.ShareLinks().Create(link); err != nil {
+		return nil, status.Errorf(codes.Internal, "db insert failed: %v", err)
+	}
+
+	return shareLinkToProto(link), nil
+}
+
+func (s *PublicServer) ListShareLinks(
+	ctx context.Context,
+	req *pb.ListShareLinksRequest,
+) (*pb.ListShareLinksResponse, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.store.ShareLinks().ListByFile(file.UUID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return &pb.ListShareLinksResponse{Links: shareLinksToProto(links)}, nil
+}
+
+func (s *PublicServer) RevokeShareLink(
+	ctx context.Context,
+	req *pb.RevokeShareLinkRequest,
+) (*pb.RevokeShareLinkResponse, error) {
+
+	linkID, err := uuid.Parse(req.LinkId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid link id")
+	}
+
+	link, err := s.store.ShareLinks().GetByID(linkID.String())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "share link not found")
+	}
+
+	if _, err := s.fileFor(ctx, link.FileUUID, access.Update); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.ShareLinks().Delete(link.UUID); err != nil {
+		return nil, status.Errorf(codes.Internal, "db delete failed: %v", err)
+	}
+
+	return &pb.RevokeShareLinkResponse{Success: true}, nil
+}
+
+func (s *PublicServer) GetSharedFile(
+	ctx context.Context,
+	req *pb.GetSharedFileRequest,
+) (*pb.File, error) {
+
+	_, file, err := s.sharedFile(req.Token, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedFileToProto(file), nil
+}
+
+// DownloadShared списывает с лимита ссылки запрошенный диапазон: докачка
+// расходует только недостающие байты, а частичные запросы не обходят лимит
+func (s *PublicServer) DownloadShared(
+	req *pb.DownloadSharedRequest,
+	stream pb.FileService_DownloadSharedServer,
+) error {
+
+	if req.Offset < 0 || req.Length < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must be non-negative")
+	}
+
+	link, file, err := s.sharedFile(req.Token, req.Password)
+	if err != nil {
+		return err
+	}
+
+	// Непроверенный файл не расходует лимит скачиваний
+	if err := checkContent(file); err != nil {
+		return err
+	}
+
+	if req.Offset > file.Size {
+		return status.Errorf(codes.OutOfRange, "offset %d is beyond file size %d", req.Offset, file.Size)
+	}
+
+	served, size := req.Length, file.Size
+	if served == 0 || served > size-req.Offset {
+		served = size - req.Offset
+	}
+	// Пустой файл расходует по единице на запрос
+	if size == 0 {
+		served, size = 1, 1
+	}
+
+	err = s.store.ShareLinks().CountDownload(link.UUID, served, size)
+	if errors.Is(err, store.ErrLimitReached) {
+		return status.Error(codes.ResourceExhausted, "share link download limit reached")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	// Анонимному получателю не отдаются идентификаторы пользователей и курсов
+	shared := &model.File{
+		UUID:           file.UUID,
+		Name:           file.Name,
+		MimeType:       file.MimeType,
+		Size:           file.Size,
+		Hash:           file.Hash,
+		CRC32C:         file.CRC32C,
+		StorageKey:     file.StorageKey,
+		CreatedAt:      file.CreatedAt,
+		CurrentVersion: file.CurrentVersion,
+		ScanResult:     file.ScanResult,
+	}
+
+	return s.sendFile(stream, shared, req.Offset, req.Length)
+}
+
+// sharedFile проверяет ссылку и пароль и возвращает файл, на который она ведет
+func (s *PublicServer) sharedFile(token string, password string) (*model.ShareLink, *model.File, error) {
+	if token == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	link, err := s.store.ShareLinks().GetByTokenHash(utils.HashToken(token))
+	if err != nil || (link.ExpiresAt != nil && link.ExpiresAt.Before(time.Now())) {
+		return nil, nil, status.Error(codes.NotFound, "share link not found or expired")
+	}
+
+	if link.PasswordHash != "" {
+		if err := s.checkSharePassword(link, password); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if link.MaxDownloads > 0 && link.Downloads >= link.MaxDownloads {
+		return nil, nil, status.Error(codes.ResourceExhausted, "share link download limit reached")
+	}
+
+	file, err := s.store.Meta().GetByID(link.FileUUID)
+	if err != nil {
+		return nil, nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	return link, file, nil
+}
+
+// checkSharePassword сверяет пароль ссылки. Проверка PBKDF2 дорогая и
+// доступна без авторизации, поэтому число попыток на ссылку ограничено
+func (s *PublicServer) checkSharePassword(link *model.ShareLink, password string) error {
+	if password == "" {
+		return status.Error(codes.PermissionDenied, "share link password is required")
+	}
+
+	if s.cfg.SharePasswordAttempts > 0 {
+		err := s.store.ShareLinks().TakePasswordAttempt(link.UUID, s.cfg.SharePasswordAttempts, s.cfg.SharePasswordWindow)
+		if errors.Is(err, store.ErrLimitReached) {
+			return status.Error(codes.ResourceExhausted, "too many share link password attempts, try again later")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "db update failed: %v", err)
+		}
+	}
+
+	if !utils.CheckPassword(link.PasswordHash, password) {
+		return status.Error(codes.PermissionDenied, "invalid share link password")
+	}
+
+	if s.cfg.SharePasswordAttempts > 0 {
+		if err := s.store.ShareLinks().ReturnPasswordAttempt(link.UUID); err != nil {
+			s.logger.Warnf("failed to return password attempt of share link %s: %s", link.UUID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package public
+
+import (
+	"io"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Размер чанка при отдаче файла стримом
+const downloadChunkSize = 64 << 10
+
+// chunkReader отдает чанки клиентского стрима как io.Reader,
+// не буферизуя файл целиком
+type chunkReader struct {
+	next func() ([]byte, error)
+	buf  []byte
+	n    int64
+	err  error
+}
+
+func newUploadReader(stream pb.FileService_UploadFileServer) *chunkReader {
+	return &chunkReader{
+		next: func() ([]byte, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			chunk, ok := req.Data.(*pb.UploadFileRequest_Chunk)
+			if !ok {
+				return nil, status.Error(codes.InvalidArgument, "metadata can only be sent in the first message")
+			}
+			return chunk.Chunk, nil
+		},
+	}
+}
+
+func newSessionReader(stream pb.FileService_WriteUploadSessionServer) *chunkReader {
+	return &chunkReader{
+		next: func() ([]byte, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			chunk, ok := req.Data.(*pb.WriteUploadSessionRequest_Chunk)
+			if !ok {
+				return nil, status.Error(codes.InvalidArgument, "header can only be sent in the first message")
+			}
+			return chunk.Chunk, nil
+		},
+	}
+}
+
+func newArchiveReader(stream pb.FileService_UploadArchiveServer) *chunkReader {
+	return &chunkReader{
+		next: func() ([]byte, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			chunk, ok := req.Data.(*pb.UploadArchiveRequest_Chunk)
+			if !ok {
+				return nil, status.Error(codes.InvalidArgument, "metadata can only be sent in the first message")
+			}
+			return chunk.Chunk, nil
+		},
+	}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		r.buf, r.err = r.next()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.n += int64(n)
+
+	return n, nil
+}
+
+// Empty дожидается первых данных и сообщает, что стрим закончился без них
+func (r *chunkReader) Empty() bool {
+	for len(r.buf) == 0 && r.err == nil {
+		r.buf, r.err = r.next()
+	}
+	return len(r.buf) == 0 && r.err == io.EOF
+}
+
+// Size возвращает количество прочитанных байт
+func (r *chunkReader) Size() int64 {
+	return r.n
+}
+
+// Err возвращает ошибку стрима, если чтение прервалось не по io.EOF
+func (r *chunkReader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// sendChunks отправляет содержимое reader в стрим чанками
+func sendChunks(stream pb.FileService_DownloadFileServer, reader io.Reader) error {
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&pb.DownloadFileResponse{
+				Data: &pb.DownloadFileResponse_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "read failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package public
+
+import (
+	"context"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/content"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTrash без курса показывает собственные удаленные файлы вызывающего,
+// с курсом - все удаленные файлы курса его преподавателю
+func (s *PublicServer) ListTrash(
+	ctx context.Context,
+	req *pb.ListTrashRequest,
+) (*pb.ListFilesResponse, error) {
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := fileQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.Trashed = true
+
+	if req.CourseId == "" {
+		query.UploaderID = userID.String()
+		return s.listFiles(query)
+	}
+
+	courseID, err := scopeID(req.CourseId, "course")
+	if err != nil {
+		return nil, err
+	}
+
+	// Удалять и восстанавливать чужие файлы курса может только преподаватель
+	if err := s.access.Authorize(ctx, access.Delete, access.Resource{CourseID: courseID}); err != nil {
+		return nil, err
+	}
+	query.CourseID = courseID
+
+	return s.listFiles(query)
+}
+
+func (s *PublicServer) RestoreFile(
+	ctx context.Context,
+	req *pb.RestoreFileRequest,
+) (*pb.File, error) {
+
+	file, err := s.trashedFile(ctx, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Meta().Restore(file.UUID); err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	file, err = s.store.Meta().GetByID(file.UUID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return fileToProto(file), nil
+}
+
+func (s *PublicServer) PurgeFile(
+	ctx context.Context,
+	req *pb.PurgeFileRequest,
+) (*pb.PurgeFileResponse, error) {
+
+	file, err := s.trashedFile(ctx, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := content.Purge(s.store, s.logger, file); err != nil {
+		return nil, status.Errorf(codes.Internal, "purge failed: %v", err)
+	}
+
+	return &pb.PurgeFileResponse{Success: true}, nil
+}
+
+// trashedFile находит файл в корзине и проверяет право им распоряжаться
+func (s *PublicServer) trashedFile(ctx context.Context, id string) (*model.File, error) {
+	fileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid file id")
+	}
+
+	file, err := s.store.Meta().GetTrashed(fileID.String())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "file not found in trash")
+	}
+
+	if err := s.access.Authorize(ctx, access.Delete, access.FileResource(file)); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
@@ -0,0 +1,289 @@
+package public
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/policy"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/unzip"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UploadArchive распаковывает ZIP: каталоги становятся папками, файлы -
+// файлами в них. Ошибка одной записи не останавливает распаковку
+// остальных, результат каждой записи возвращается в отчете
+func (s *PublicServer) UploadArchive(stream pb.FileService_UploadArchiveServer) error {
+	ctx := stream.Context()
+
+	userID, err := utils.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive metadata: %v", err)
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain metadata")
+	}
+
+	dest, err := s.resolveUpload(ctx, "", meta.FolderId, meta.CourseId, meta.GroupId)
+	if err != nil {
+		return err
+	}
+
+	// Оглавление ZIP лежит в конце, поэтому архив сначала сохраняется
+	// во временный файл
+	tmp, err := os.CreateTemp("", "upload-archive-*.zip")
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	reader := newArchiveReader(stream)
+
+	var body io.Reader = reader
+	if maxSize := s.cfg.Unpack.MaxArchiveSize; maxSize > 0 {
+		body = io.LimitReader(reader, maxSize+1)
+	}
+
+	size, err := io.Copy(tmp, body)
+	if streamErr := reader.Err(); streamErr != nil {
+		return status.Errorf(codes.Aborted, "upload stream failed: %v", streamErr)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to save archive: %v", err)
+	}
+
+	if maxSize := s.cfg.Unpack.MaxArchiveSize; maxSize > 0 && size > maxSize {
+		return status.Errorf(codes.InvalidArgument, "archive exceeds %d bytes", maxSize)
+	}
+
+	archive, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "not a valid zip archive: %v", err)
+	}
+
+	entries, err := unzip.Entries(archive, s.cfg.Unpack)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	u := &unpacker{
+		server:  s,
+		userID:  userID.String(),
+		dest:    dest,
+		folders: make(map[string]string),
+	}
+
+	resp := &pb.UploadArchiveResponse{}
+	for _, entry := range entries {
+		result := u.unpack(entry)
+		if result.Error != "" {
+			resp.Failed++
+		} else if result.FileId != "" {
+			resp.FilesCreated++
+		}
+
+		resp.Entries = append(resp.Entries, result)
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// unpacker создает файлы и папки из записей одного архива
+type unpacker struct {
+	server *PublicServer
+	userID string
+	dest   uploadDest
+
+	// Путь каталога в архиве -> id папки
+	folders map[string]string
+}
+
+func (u *unpacker) unpack(entry *unzip.Entry) *pb.ArchiveEntryResult {
+	result := &pb.ArchiveEntryResult{Path: entry.Path, Size: entry.Size}
+
+	err := entry.Err
+	if err == nil {
+		result.FolderId, err = u.folder(entry.Dirs)
+	}
+	if err == nil && !entry.IsDir() {
+		var file *model.File
+		if file, err = u.extract(entry, result.FolderId); err == nil {
+			result.FileId = file.UUID
+		}
+	}
+
+	if err != nil {
+		result.Error = status.Convert(err).Message()
+	}
+	return result
+}
+
+// folder возвращает папку каталога dirs, создавая недостающие.
+// Папки с тем же именем, что уже есть в месте назначения, переиспользуются
+func (u *unpacker) folder(dirs []string) (string, error) {
+	parentID := u.dest.folderID
+
+	for i := range dirs {
+		path := strings.Join(dirs[:i+1], "/")
+		if id, ok := u.folders[path]; ok {
+			parentID = id
+			continue
+		}
+
+		name, err := folderName(dirs[i])
+		if err != nil {
+			return "", err
+		}
+
+		id, err := u.childFolder(parentID, name)
+		if err != nil {
+			return "", err
+		}
+
+		u.folders[path] = id
+		parentID = id
+	}
+
+	return parentID, nil
+}
+
+func (u *unpacker) childFolder(parentID string, name string) (string, error) {
+	folders := u.server.store.Folders()
+
+	children, err := folders.ListChildren(parentID, u.dest.courseID, u.dest.groupID, u.userID)
+	if err != nil {
+		return "", status.Error(codes.Internal, "db error")
+	}
+	for _, child := range children {
+		if child.Name == name {
+			return child.UUID, nil
+		}
+	}
+
+	folder := &model.Folder{
+		UUID:      uuid.New().String(),
+		Name:      name,
+		ParentID:  parentID,
+		CourseID:  u.dest.courseID,
+		GroupID:   u.dest.groupID,
+		CreatorID: u.userID,
+	}
+	if err := folders.Create(folder); err != nil {
+		return "", status.Errorf(codes.Internal, "db insert failed: %v", err)
+	}
+
+	return folder.UUID, nil
+}
+
+// extract загружает содержимое записи так же, как UploadFile:
+// проверка политики по первым байтам, временный объект, blob по хешу
+func (u *unpacker) extract(entry *unzip.Entry, folderID string) (*model.File, error) {
+	s := u.server
+
+	name, err := fileName(entry.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := u.dest
+	dest.folderID = folderID
+
+	if err := s.checkQuota(u.userID, dest, entry.Size); err != nil {
+		return nil, err
+	}
+
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to open entry: %v", err)
+	}
+	defer rc.Close()
+
+	src := &entryReader{r: rc}
+	head := bufio.NewReaderSize(src, policy.SniffLen)
+	sniffed, _ := head.Peek(policy.SniffLen)
+
+	fileID := uuid.New()
+	file := &model.File{
+		UUID:       fileID.String(),
+		Name:       name,
+		MimeType:   policy.Detect(sniffed, name),
+		Size:       entry.Size,
+		UploaderID: u.userID,
+		CourseID:   dest.courseID,
+		GroupID:    dest.groupID,
+		FolderID:   dest.folderID,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.checkPolicy(dest.courseID, file); err != nil {
+		return nil, err
+	}
+
+	stagingKey := fmt.Sprintf("files/%s", fileID)
+	hasher := checksum.New()
+	body := io.TeeReader(head, hasher)
+
+	err = s.store.CourseFile(dest.courseID).Put(stagingKey, body, entry.Size, file.MimeType)
+	if err == nil {
+		// CRC-32 записи сверяется только при чтении до конца
+		_, err = io.Copy(io.Discard, body)
+	}
+	if err != nil {
+		s.dropObject(stagingKey)
+
+		if src.err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "corrupted entry: %v", src.err)
+		}
+		return nil, status.Errorf(codes.Internal, "minio upload failed: %v", err)
+	}
+
+	blob, err := s.linkBlob(dest.courseID, stagingKey, hasher.Sum(), entry.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Size = blob.Size
+	file.Hash = blob.Hash
+	file.CRC32C = blob.CRC32C
+	file.StorageKey = blob.StorageKey
+
+	if err := s.saveFile(file); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// entryReader запоминает ошибку чтения записи, чтобы отличить
+// поврежденный архив от сбоя хранилища
+type entryReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *entryReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+	return n, err
+}
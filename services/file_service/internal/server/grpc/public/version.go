@@ -0,0 +1,133 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/alexey-dobry/fileshare/pkg/gen/file/pubfile"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/access"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *PublicServer) ListFileVersions(
+	ctx context.Context,
+	req *pb.ListFileVersionsRequest,
+) (*pb.ListFileVersionsResponse, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Get)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.store.Meta().ListVersions(file.UUID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "db error")
+	}
+
+	return &pb.ListFileVersionsResponse{
+		Versions:       versionsToProto(versions),
+		CurrentVersion: file.CurrentVersion,
+	}, nil
+}
+
+func (s *PublicServer) DownloadFileVersion(
+	req *pb.DownloadFileVersionRequest,
+	stream pb.FileService_DownloadFileVersionServer,
+) error {
+
+	if req.Offset < 0 || req.Length < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must be non-negative")
+	}
+
+	file, err := s.fileFor(stream.Context(), req.FileId, access.Download)
+	if err != nil {
+		return err
+	}
+
+	version, err := s.store.Meta().GetVersion(file.UUID, req.Version)
+	if err != nil {
+		return status.Error(codes.NotFound, "version not found")
+	}
+
+	return s.sendFile(stream, fileAtVersion(file, version), req.Offset, req.Length)
+}
+
+func (s *PublicServer) RestoreFileVersion(
+	ctx context.Context,
+	req *pb.RestoreFileVersionRequest,
+) (*pb.File, error) {
+
+	file, err := s.fileFor(ctx, req.FileId, access.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Meta().GetVersion(file.UUID, req.Version); err != nil {
+		return nil, status.Error(codes.NotFound, "version not found")
+	}
+
+	file, err = s.store.Meta().SetCurrentVersion(file.UUID, req.Version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "db update failed: %v", err)
+	}
+
+	return fileToProto(file), nil
+}
+
+// commitFile сохраняет загрузку новым файлом, а при заданном targetID -
+// новой версией существующего файла
+func (s *PublicServer) commitFile(targetID string, file *model.File) (*model.File, error) {
+	if targetID == "" {
+		if err := s.saveFile(file); err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+
+	version := &model.FileVersion{
+		MimeType:   file.MimeType,
+		Size:       file.Size,
+		Hash:       file.Hash,
+		CRC32C:     file.CRC32C,
+		StorageKey: file.StorageKey,
+		UploaderID: file.UploaderID,
+		CreatedAt:  time.Now(),
+	}
+
+	updated, pruned, err := s.store.Meta().AddVersion(targetID, version, s.cfg.VersionRetention)
+	if err != nil {
+		if releaseErr := s.releaseContent(file.Hash, file.StorageKey); releaseErr != nil {
+			s.logger.Warnf("failed to release content of new version of %s: %s", targetID, releaseErr)
+		}
+		return nil, writeError(err)
+	}
+
+	s.releaseVersions(targetID, pruned)
+
+	return updated, nil
+}
+
+// releaseVersions освобождает содержимое удаленных версий
+func (s *PublicServer) releaseVersions(fileID string, versions []*model.FileVersion) {
+	for _, v := range versions {
+		if err := s.releaseContent(v.Hash, v.StorageKey); err != nil {
+			s.logger.Warnf("failed to release content of version %d of %s: %s", v.Version, fileID, err)
+		}
+	}
+}
+
+// fileAtVersion возвращает копию файла с содержимым указанной версии
+func fileAtVersion(file *model.File, version *model.FileVersion) *model.File {
+	f := *file
+	f.CurrentVersion = version.Version
+	f.MimeType = version.MimeType
+	f.Size = version.Size
+	f.Hash = version.Hash
+	f.CRC32C = version.CRC32C
+	f.StorageKey = version.StorageKey
+	f.ScanResult = version.ScanResult
+	f.ContentMissing = version.ContentMissing
+	return &f
+}
@@ -0,0 +1,21 @@
+package store
+
+import "errors"
+
+// ErrConflict - запись была изменена конкурентно
+var ErrConflict = errors.New("conflict")
+
+// ErrQuotaExceeded - запись превысила бы лимит занятого объема
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ErrLimitReached - исчерпан лимит использований
+var ErrLimitReached = errors.New("limit reached")
+
+// ErrObjectNotFound - объекта нет в хранилище
+var ErrObjectNotFound = errors.New("object not found")
+
+// ErrNotSupported - драйвер хранилища не поддерживает операцию
+var ErrNotSupported = errors.New("not supported by storage driver")
+
+// ErrFolderCycle - папку пытаются переместить внутрь нее самой
+var ErrFolderCycle = errors.New("folder cycle")
@@ -1,11 +1,24 @@
 package file
 
 import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/local"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/minio"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg"
 )
 
 type Config struct {
-	PgConfig    pg.Config    `yaml:"pg_config"`
-	MinioConfig minio.Config `yaml:"minio_config"`
+	PgConfig pg.Config `yaml:"pg_config"`
+
+	// Driver - хранилище содержимого: minio, local (каталог на диске)
+	// или memory (память процесса, содержимое теряется при перезапуске,
+	// только для тестов). Presigned ссылки есть только у minio.
+	// Настройки MinIO проверяются, только если он выбран
+	Driver      string       `yaml:"driver" env-default:"minio" validate:"oneof=minio local memory"`
+	MinioConfig minio.Config `yaml:"minio_config" validate:"-"`
+	LocalConfig local.Config `yaml:"local_config"`
+
+	Quota pg.QuotaConfig `yaml:"quota"`
+
+	Encryption envelope.Config `yaml:"encryption"`
 }
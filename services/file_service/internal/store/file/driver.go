@@ -0,0 +1,103 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/pkg/validator"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/local"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/memory"
+	mn "github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/minio"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Content - репозитории хранилища содержимого, выбранного Driver
+type Content struct {
+	File      store.FileRepository
+	Multipart store.MultipartRepository
+	Presign   store.PresignRepository
+}
+
+// NewContent открывает хранилище содержимого без базы метаданных
+func NewContent(logger logger.Logger, cfg Config) (*Content, error) {
+	switch cfg.Driver {
+	case "local":
+		repo, err := local.New(logger, cfg.LocalConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &Content{File: repo, Multipart: repo, Presign: noPresign{}}, nil
+	case "memory":
+		repo := memory.New(logger)
+		return &Content{File: repo, Multipart: repo, Presign: noPresign{}}, nil
+	default:
+		return newMinio(logger, cfg.MinioConfig)
+	}
+}
+
+func newMinio(logger logger.Logger, cfg mn.Config) (*Content, error) {
+	if err := validator.V.Struct(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid minio config: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
+	accessKey := cfg.AccessKey
+	secretAccessKey := cfg.SecretKey
+	useSSL := false
+
+	minioDB, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretAccessKey, ""),
+		Secure: useSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Presigned ссылки подписываются под адрес, по которому ходят клиенты
+	presignDB := minioDB
+	if cfg.PublicEndpoint != "" {
+		presignDB, err = minio.New(cfg.PublicEndpoint, &minio.Options{
+			Creds:  credentials.NewStaticV4(accessKey, secretAccessKey, ""),
+			Secure: cfg.PublicSSL,
+			Region: cfg.Region,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := minioDB.BucketExists(context.Background(), cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if err := minioDB.MakeBucket(context.Background(), cfg.Bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Content{
+		File:      mn.New(minioDB, logger, cfg.Bucket),
+		Multipart: mn.NewMultipart(minioDB, logger, cfg.Bucket),
+		Presign:   mn.NewPresign(presignDB, logger, cfg.Bucket),
+	}, nil
+}
+
+// noPresign - драйверы без прямого доступа клиентов к хранилищу,
+// содержимое передается только через сервис
+type noPresign struct{}
+
+func (noPresign) PresignPut(string, string, time.Duration) (string, http.Header, error) {
+	return "", nil, store.ErrNotSupported
+}
+
+func (noPresign) PresignGet(string, string, time.Duration) (string, error) {
+	return "", store.ErrNotSupported
+}
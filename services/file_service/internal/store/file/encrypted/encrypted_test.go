@@ -0,0 +1,208 @@
+package encrypted
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/memory"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/storetest"
+)
+
+// keys - KeyRepository в памяти
+type keys struct {
+	mu   sync.Mutex
+	rows []model.DataKey
+}
+
+func (k *keys) GetOrCreate(courseID string, create func() (*model.DataKey, error)) (*model.DataKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, row := range k.rows {
+		if row.CourseID == courseID {
+			return &row, nil
+		}
+	}
+
+	key, err := create()
+	if err != nil {
+		return nil, err
+	}
+	key.ID = uint64(len(k.rows) + 1)
+	k.rows = append(k.rows, *key)
+	return key, nil
+}
+
+func (k *keys) GetByID(id uint64) (*model.DataKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, row := range k.rows {
+		if row.ID == id {
+			return &row, nil
+		}
+	}
+	return nil, store.ErrObjectNotFound
+}
+
+func (k *keys) ListNotWrappedBy(masterKeyID string, afterID uint64, limit int) ([]*model.DataKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var found []*model.DataKey
+	for _, row := range k.rows {
+		if row.MasterKeyID != masterKeyID && row.ID > afterID && len(found) < limit {
+			found = append(found, &row)
+		}
+	}
+	return found, nil
+}
+
+func (k *keys) Rewrap(key *model.DataKey, oldMasterKeyID string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for i, row := range k.rows {
+		if row.ID == key.ID && row.MasterKeyID == oldMasterKeyID {
+			k.rows[i] = *key
+			return nil
+		}
+	}
+	return store.ErrConflict
+}
+
+func masterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newKeyring(t *testing.T, keys store.KeyRepository, current string, masters map[string]string) *envelope.Keyring {
+	keyring, err := envelope.New(envelope.Config{
+		Enabled:     true,
+		MasterKeyID: current,
+		MasterKeys:  masters,
+	}, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func read(t *testing.T, files store.FileRepository, key string, offset, length int64) []byte {
+	reader, err := files.GetRange(key, offset, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestConformance(t *testing.T) {
+	inner := memory.New(zap.NewLogger(zap.Config{Dir: t.TempDir()}))
+	keyring := newKeyring(t, &keys{}, "a", map[string]string{"a": masterKey(t)})
+
+	repo := New(inner, inner, keyring).ForCourse("course")
+	if err := storetest.Run(repo, repo); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncryptedAtRest(t *testing.T) {
+	inner := memory.New(zap.NewLogger(zap.Config{Dir: t.TempDir()}))
+	keyring := newKeyring(t, &keys{}, "a", map[string]string{"a": masterKey(t)})
+	repo := New(inner, inner, keyring).ForCourse("course")
+
+	data := bytes.Repeat([]byte("fileshare "), envelope.ChunkSize/5)
+	if err := repo.Put("object", bytes.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := read(t, inner, "object", 0, 0)
+	if bytes.Contains(stored, data[:64]) {
+		t.Fatal("content is stored in plaintext")
+	}
+	if int64(len(stored)) != envelope.SealedSize(int64(len(data))) {
+		t.Fatalf("stored %d bytes, want %d", len(stored), envelope.SealedSize(int64(len(data))))
+	}
+
+	// Диапазон на стыке чанков
+	offset := int64(envelope.ChunkSize - 7)
+	if got := read(t, repo, "object", offset, 20); !bytes.Equal(got, data[offset:offset+20]) {
+		t.Fatal("range across chunks does not match")
+	}
+
+	stored[envelope.HeaderSize+1] ^= 1
+	if err := inner.Put("tampered", bytes.NewReader(stored), int64(len(stored)), ""); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := repo.Get("tampered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(reader); err == nil {
+		t.Fatal("tampered content was read")
+	}
+}
+
+func TestPlaintextObjects(t *testing.T) {
+	inner := memory.New(zap.NewLogger(zap.Config{Dir: t.TempDir()}))
+	keyring := newKeyring(t, &keys{}, "a", map[string]string{"a": masterKey(t)})
+	repo := New(inner, inner, keyring)
+
+	if err := inner.Put("legacy", bytes.NewReader([]byte("plain content")), 13, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := read(t, repo, "legacy", 0, 0); string(got) != "plain content" {
+		t.Fatalf("read %q", got)
+	}
+	if got := read(t, repo, "legacy", 6, 3); string(got) != "con" {
+		t.Fatalf("read range %q", got)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	inner := memory.New(zap.NewLogger(zap.Config{Dir: t.TempDir()}))
+	dataKeys := &keys{}
+	oldKey, newKey := masterKey(t), masterKey(t)
+
+	repo := New(inner, inner, newKeyring(t, dataKeys, "old", map[string]string{"old": oldKey}))
+	for _, course := range []string{"a", "b", "c"} {
+		if err := repo.ForCourse(course).Put(course, bytes.NewReader([]byte(course)), 1, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rotating := newKeyring(t, dataKeys, "new", map[string]string{"old": oldKey, "new": newKey})
+	rotated, err := rotating.Rotate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != 3 {
+		t.Fatalf("rotated %d keys, want 3", rotated)
+	}
+
+	// Прежний мастер-ключ больше не нужен, содержимое не перезаписывалось
+	repo = New(inner, inner, newKeyring(t, dataKeys, "new", map[string]string{"new": newKey}))
+	for _, course := range []string{"a", "b", "c"} {
+		if got := read(t, repo, course, 0, 0); string(got) != course {
+			t.Fatalf("read %q, want %q", got, course)
+		}
+	}
+}
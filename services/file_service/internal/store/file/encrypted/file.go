@@ -0,0 +1,234 @@
+package encrypted
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+)
+
+func (r *Repository) Put(
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) error {
+
+	if !r.keys.Enabled() {
+		return r.files.Put(key, reader, size, contentType)
+	}
+
+	header, sealer, err := r.sealer()
+	if err != nil {
+		return err
+	}
+
+	sealedSize, last := int64(-1), int64(-1)
+	if size >= 0 {
+		reader = io.LimitReader(reader, size)
+		sealedSize = envelope.SealedSize(size)
+		last = envelope.Chunks(size) - 1
+	}
+
+	sealed := newSealingReader(reader, sealer, header.Marshal(), 0, last)
+	return r.files.Put(key, sealed, sealedSize, contentType)
+}
+
+func (r *Repository) Get(key string) (io.ReadCloser, error) {
+	return r.GetRange(key, 0, 0)
+}
+
+// GetRange читает length байт открытого текста начиная с offset,
+// length <= 0 - до конца объекта. Из хранилища читаются только чанки,
+// в которые попадает диапазон
+func (r *Repository) GetRange(key string, offset, length int64) (io.ReadCloser, error) {
+	info, err := r.files.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// С начала объекта заголовок читается из того же потока
+	if offset == 0 {
+		return r.getFromStart(key, info.Size, length)
+	}
+
+	header, ok, err := r.header(key, info.Size)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return r.files.GetRange(key, offset, length)
+	}
+
+	size, length, err := plainRange(info.Size, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	first := offset / envelope.ChunkSize
+	end := (offset + length - 1) / envelope.ChunkSize
+
+	start := chunkOffset(first)
+	stop := min(chunkOffset(end+1), info.Size)
+
+	src, err := r.files.GetRange(key, start, stop-start)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.open(src, header, info.Size, size, offset, length)
+}
+
+func (r *Repository) getFromStart(key string, sealedSize int64, length int64) (io.ReadCloser, error) {
+	src, err := r.files.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, envelope.HeaderSize)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		_ = src.Close()
+		return nil, err
+	}
+
+	header, ok := envelope.ParseHeader(head[:n])
+	if !ok {
+		var plain io.Reader = io.MultiReader(bytes.NewReader(head[:n]), src)
+		if length > 0 {
+			plain = io.LimitReader(plain, length)
+		}
+		return readCloser{Reader: plain, Closer: src}, nil
+	}
+
+	size, length, err := plainRange(sealedSize, 0, length)
+	if err != nil {
+		_ = src.Close()
+		return nil, err
+	}
+
+	return r.open(src, header, sealedSize, size, 0, length)
+}
+
+// open расшифровывает src, который начинается с чанка, содержащего offset
+func (r *Repository) open(
+	src io.ReadCloser,
+	header envelope.Header,
+	sealedSize int64,
+	size int64,
+	offset int64,
+	length int64,
+) (io.ReadCloser, error) {
+
+	opener, err := r.opener(header)
+	if err != nil {
+		_ = src.Close()
+		return nil, err
+	}
+
+	first := offset / envelope.ChunkSize
+	last := envelope.Chunks(size) - 1
+
+	return &openingReader{
+		src:       src,
+		opener:    opener,
+		index:     uint64(first),
+		last:      uint64(last),
+		lastSize:  int(sealedSize - chunkOffset(last)),
+		skip:      int(offset - first*envelope.ChunkSize),
+		remaining: length,
+		sealed:    make([]byte, envelope.ChunkSize+envelope.Overhead),
+	}, nil
+}
+
+// plainRange возвращает размер открытого текста и длину диапазона,
+// обрезанную по концу объекта
+func plainRange(sealedSize int64, offset, length int64) (int64, int64, error) {
+	size, ok := envelope.PlainSize(sealedSize)
+	if !ok {
+		return 0, 0, envelope.ErrCorrupted
+	}
+
+	if offset > size {
+		return 0, 0, fmt.Errorf("offset %d is beyond object size %d", offset, size)
+	}
+
+	if length <= 0 || length > size-offset {
+		length = size - offset
+	}
+	return size, length, nil
+}
+
+// chunkOffset - смещение чанка index в зашифрованном объекте
+func chunkOffset(index int64) int64 {
+	return int64(envelope.HeaderSize) + index*(envelope.ChunkSize+envelope.Overhead)
+}
+
+// header читает заголовок объекта, ok - false, если объект не зашифрован
+func (r *Repository) header(key string, sealedSize int64) (envelope.Header, bool, error) {
+	if sealedSize < int64(envelope.HeaderSize) {
+		return envelope.Header{}, false, nil
+	}
+
+	reader, err := r.files.GetRange(key, 0, int64(envelope.HeaderSize))
+	if err != nil {
+		return envelope.Header{}, false, err
+	}
+	defer reader.Close()
+
+	head := make([]byte, envelope.HeaderSize)
+	if _, err := io.ReadFull(reader, head); err != nil {
+		return envelope.Header{}, false, err
+	}
+
+	header, ok := envelope.ParseHeader(head)
+	return header, ok, nil
+}
+
+func (r *Repository) Delete(key string) error {
+	return r.files.Delete(key)
+}
+
+// Stat возвращает размер открытого текста
+func (r *Repository) Stat(key string) (*model.StorageObjectInfo, error) {
+	info, err := r.files.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok, err := r.header(key, info.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		size, valid := envelope.PlainSize(info.Size)
+		if !valid {
+			return nil, envelope.ErrCorrupted
+		}
+		info.Size = size
+	}
+
+	return info, nil
+}
+
+// Copy копирует объект как есть: копия остается зашифрованной
+// ключом курса оригинала
+func (r *Repository) Copy(srcKey string, dstKey string) error {
+	return r.files.Copy(srcKey, dstKey)
+}
+
+func (r *Repository) List(prefix string, startAfter string, limit int) ([]*model.StorageObjectInfo, error) {
+	return r.files.List(prefix, startAfter, limit)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,109 @@
+package encrypted
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+// Заголовок и размер шифруемой загрузки дописываются к ID загрузки
+// хранилища, поэтому части можно принимать на любой реплике
+const uploadSeparator = "#"
+
+func encodeUpload(uploadID string, header envelope.Header, size int64) string {
+	params := binary.BigEndian.AppendUint64(header.Marshal(), uint64(size))
+	return uploadID + uploadSeparator + base64.RawURLEncoding.EncodeToString(params)
+}
+
+// decodeUpload разбирает ID шифруемой загрузки, ok - false для
+// загрузок, начатых без шифрования
+func decodeUpload(uploadID string) (string, envelope.Header, int64, bool) {
+	id, encoded, found := strings.Cut(uploadID, uploadSeparator)
+	if !found {
+		return uploadID, envelope.Header{}, 0, false
+	}
+
+	params, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(params) != envelope.HeaderSize+8 {
+		return uploadID, envelope.Header{}, 0, false
+	}
+
+	header, ok := envelope.ParseHeader(params)
+	if !ok {
+		return uploadID, envelope.Header{}, 0, false
+	}
+
+	size := int64(binary.BigEndian.Uint64(params[envelope.HeaderSize:]))
+	return id, header, size, true
+}
+
+func (r *Repository) NewMultipart(key string, contentType string, size int64) (string, error) {
+	if !r.keys.Enabled() {
+		return r.multipart.NewMultipart(key, contentType, size)
+	}
+
+	header, _, err := r.sealer()
+	if err != nil {
+		return "", err
+	}
+
+	uploadID, err := r.multipart.NewMultipart(key, contentType, envelope.SealedSize(size))
+	if err != nil {
+		return "", err
+	}
+
+	return encodeUpload(uploadID, header, size), nil
+}
+
+// PutPart шифрует часть с чанка, которым она начинается в объекте:
+// смещение части известно из номера, так как все части, кроме
+// последней, размером PartSize. Заголовок пишется в первую часть
+func (r *Repository) PutPart(
+	key string,
+	uploadID string,
+	partNumber int,
+	reader io.Reader,
+	size int64,
+) error {
+
+	id, header, total, ok := decodeUpload(uploadID)
+	if !ok {
+		return r.multipart.PutPart(key, uploadID, partNumber, reader, size)
+	}
+
+	offset := int64(partNumber-1) * store.PartSize
+	if partNumber < 1 || size < 0 || offset+size > total || (offset+size < total && size != store.PartSize) {
+		return fmt.Errorf("part %d of %d bytes does not fit an object of %d bytes", partNumber, size, total)
+	}
+
+	sealer, err := r.opener(header)
+	if err != nil {
+		return err
+	}
+
+	var prefix []byte
+	if partNumber == 1 {
+		prefix = header.Marshal()
+	}
+
+	sealedSize := int64(len(prefix)) + size + envelope.Chunks(size)*envelope.Overhead
+	last := envelope.Chunks(total) - 1
+
+	sealed := newSealingReader(io.LimitReader(reader, size), sealer, prefix, uint64(offset/envelope.ChunkSize), last)
+	return r.multipart.PutPart(key, id, partNumber, sealed, sealedSize)
+}
+
+func (r *Repository) CompleteMultipart(key string, uploadID string) error {
+	id, _, _, _ := decodeUpload(uploadID)
+	return r.multipart.CompleteMultipart(key, id)
+}
+
+func (r *Repository) AbortMultipart(key string, uploadID string) error {
+	id, _, _, _ := decodeUpload(uploadID)
+	return r.multipart.AbortMultipart(key, id)
+}
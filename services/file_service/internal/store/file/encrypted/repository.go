@@ -0,0 +1,63 @@
+package encrypted
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+// Repository шифрует содержимое при записи ключом курса courseID
+// и расшифровывает при чтении ключом из заголовка объекта. Объекты
+// без заголовка, записанные до включения шифрования, читаются как есть.
+// Copy, Delete и List работают с объектами в хранилище без изменений,
+// поэтому List возвращает размер зашифрованного объекта
+type Repository struct {
+	files     store.FileRepository
+	multipart store.MultipartRepository
+	keys      *envelope.Keyring
+	courseID  string
+}
+
+func New(files store.FileRepository, multipart store.MultipartRepository, keys *envelope.Keyring) *Repository {
+	return &Repository{
+		files:     files,
+		multipart: multipart,
+		keys:      keys,
+	}
+}
+
+// ForCourse возвращает репозиторий, записывающий ключом курса courseID
+func (r *Repository) ForCourse(courseID string) *Repository {
+	course := *r
+	course.courseID = courseID
+	return &course
+}
+
+// sealer возвращает заголовок и шифратор нового объекта курса
+func (r *Repository) sealer() (envelope.Header, *envelope.Sealer, error) {
+	keyID, key, err := r.keys.CourseKey(r.courseID)
+	if err != nil {
+		return envelope.Header{}, nil, err
+	}
+
+	header, err := envelope.NewHeader(keyID)
+	if err != nil {
+		return envelope.Header{}, nil, err
+	}
+
+	sealer, err := envelope.NewSealer(header, key)
+	if err != nil {
+		return envelope.Header{}, nil, err
+	}
+
+	return header, sealer, nil
+}
+
+// opener возвращает шифратор объекта с заголовком header
+func (r *Repository) opener(header envelope.Header) (*envelope.Sealer, error) {
+	key, err := r.keys.Key(header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope.NewSealer(header, key)
+}
@@ -0,0 +1,155 @@
+package encrypted
+
+import (
+	"errors"
+	"io"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+)
+
+// sealingReader шифрует src по чанкам, отдавая сначала prefix
+// (заголовок объекта или пусто для продолжения загрузки по частям)
+type sealingReader struct {
+	src    io.Reader
+	sealer *envelope.Sealer
+	index  uint64
+	// last - номер последнего чанка объекта, -1 - последний тот,
+	// после которого src закончился
+	last int64
+
+	plain  []byte
+	sealed []byte
+	peek   []byte
+	out    []byte
+	done   bool
+}
+
+func newSealingReader(src io.Reader, sealer *envelope.Sealer, prefix []byte, index uint64, last int64) *sealingReader {
+	return &sealingReader{
+		src:    src,
+		sealer: sealer,
+		index:  index,
+		last:   last,
+		plain:  make([]byte, envelope.ChunkSize),
+		sealed: make([]byte, 0, envelope.ChunkSize+envelope.Overhead),
+		out:    prefix,
+	}
+}
+
+func (r *sealingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *sealingReader) next() error {
+	filled := copy(r.plain, r.peek)
+	r.peek = nil
+
+	n, err := io.ReadFull(r.src, r.plain[filled:])
+	filled += n
+
+	eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !eof {
+		return err
+	}
+
+	final := eof
+	if r.last >= 0 {
+		final = int64(r.index) == r.last
+	} else if !eof {
+		// Чанк полный: последний ли он, видно только по следующему байту
+		var next [1]byte
+		m, err := io.ReadFull(r.src, next[:])
+		switch {
+		case errors.Is(err, io.EOF):
+			final = true
+		case err != nil:
+			return err
+		default:
+			r.peek = next[:m]
+		}
+	}
+
+	r.out = r.sealer.Seal(r.sealed[:0], r.plain[:filled], r.index, final)
+	r.index++
+	// Короткий src при известном последнем чанке дает короткий
+	// объект, его отвергнет проверка размера при записи
+	r.done = final || eof
+	return nil
+}
+
+// openingReader расшифровывает чанки с index по last включительно,
+// пропуская skip байт первого и отдавая не больше remaining байт
+type openingReader struct {
+	src    io.ReadCloser
+	opener *envelope.Sealer
+	index  uint64
+	last   uint64
+	// lastSize - размер последнего чанка объекта вместе с тегом
+	lastSize int
+
+	skip      int
+	remaining int64
+
+	sealed []byte
+	out    []byte
+}
+
+func (r *openingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.remaining == 0 || r.index > r.last {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	if int64(len(r.out)) > r.remaining {
+		r.out = r.out[:r.remaining]
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	r.remaining -= int64(n)
+	return n, nil
+}
+
+func (r *openingReader) next() error {
+	size := envelope.ChunkSize + envelope.Overhead
+	final := r.index == r.last
+	if final {
+		size = r.lastSize
+	}
+
+	if _, err := io.ReadFull(r.src, r.sealed[:size]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	plain, err := r.opener.Open(r.sealed[:size], r.index, final)
+	if err != nil {
+		return err
+	}
+
+	r.out = plain[min(r.skip, len(plain)):]
+	r.skip = 0
+	r.index++
+	return nil
+}
+
+func (r *openingReader) Close() error {
+	return r.src.Close()
+}
@@ -0,0 +1,10 @@
+package local
+
+type Config struct {
+	// Root - каталог хранилища, создается при запуске
+	Root string `yaml:"root" env-default:"./data/storage"`
+
+	// ShardDepth - уровни подкаталогов из первых байт хеша ключа,
+	// по 256 на уровень, чтобы каталоги не разрастались
+	ShardDepth int `yaml:"shard_depth" env-default:"2" validate:"min=0,max=4"`
+}
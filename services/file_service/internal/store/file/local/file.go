@@ -0,0 +1,237 @@
+package local
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+func (r *Repository) Put(
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) error {
+
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	hasher := md5.New()
+
+	tmp, err := r.stage(func(w io.Writer) error {
+		return copyExact(io.MultiWriter(w, hasher), reader, size)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.writeObject(tmp, path, objectMeta{
+		ContentType: contentType,
+		ETag:        hex.EncodeToString(hasher.Sum(nil)),
+	})
+}
+
+// copyExact копирует ровно size байт, size -1 - до конца reader
+func copyExact(w io.Writer, reader io.Reader, size int64) error {
+	if size < 0 {
+		_, err := io.Copy(w, reader)
+		return err
+	}
+
+	n, err := io.CopyN(w, reader, size)
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("object is %d bytes, expected %d: %w", n, size, io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+func (r *Repository) Get(key string) (io.ReadCloser, error) {
+	return r.GetRange(key, 0, 0)
+}
+
+// GetRange читает length байт начиная с offset, length <= 0 - до конца объекта
+func (r *Repository) GetRange(key string, offset, length int64) (io.ReadCloser, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	if offset > info.Size() {
+		_ = file.Close()
+		return nil, fmt.Errorf("offset %d is beyond object size %d", offset, info.Size())
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	if length <= 0 {
+		return file, nil
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+func (r *Repository) Delete(key string) error {
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(path + metaSuffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) Stat(key string) (*model.StorageObjectInfo, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.stat(key, path)
+}
+
+func (r *Repository) stat(key string, path string) (*model.StorageObjectInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	meta, err := r.readMeta(path)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	return &model.StorageObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  meta.ContentType,
+		ETag:         meta.ETag,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (r *Repository) Copy(srcKey string, dstKey string) error {
+	srcPath, err := r.path(srcKey)
+	if err != nil {
+		return err
+	}
+	dstPath, err := r.path(dstKey)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return objectError(err)
+	}
+	defer src.Close()
+
+	meta, err := r.readMeta(srcPath)
+	if err != nil {
+		return objectError(err)
+	}
+
+	tmp, err := r.stage(func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.writeObject(tmp, dstPath, meta)
+}
+
+// List обходит все объекты: порядок ключей не совпадает с порядком
+// каталогов, поэтому страница собирается из полного списка
+func (r *Repository) List(prefix string, startAfter string, limit int) ([]*model.StorageObjectInfo, error) {
+	type entry struct {
+		key  string
+		path string
+	}
+
+	var entries []entry
+
+	err := filepath.WalkDir(filepath.Join(r.root, objectsDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(d.Name(), metaSuffix) {
+			return nil
+		}
+
+		key, ok := keyOf(d.Name())
+		if !ok || !strings.HasPrefix(key, prefix) || key <= startAfter {
+			return nil
+		}
+
+		entries = append(entries, entry{key: key, path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return strings.Compare(a.key, b.key)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	infos := make([]*model.StorageObjectInfo, 0, len(entries))
+	for _, e := range entries {
+		object, err := r.stat(e.key, e.path)
+		// Удален во время обхода
+		if errors.Is(err, store.ErrObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, object)
+	}
+
+	return infos, nil
+}
+
+// objectError переводит отсутствие файла в ErrObjectNotFound
+func objectError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return store.ErrObjectNotFound
+	}
+	return err
+}
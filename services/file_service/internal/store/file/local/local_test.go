@@ -0,0 +1,24 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/storetest"
+)
+
+func TestConformance(t *testing.T) {
+	for _, depth := range []int{0, 2} {
+		repo, err := New(zap.NewLogger(zap.Config{Dir: t.TempDir()}), Config{
+			Root:       t.TempDir(),
+			ShardDepth: depth,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := storetest.Run(repo, repo); err != nil {
+			t.Fatalf("shard depth %d: %s", depth, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package local
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Файл с описанием загрузки в ее каталоге, части лежат рядом
+// в файлах part-<номер>
+const uploadFile = "upload.json"
+
+const partPrefix = "part-"
+
+// upload - описание незавершенной multipart загрузки
+type upload struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+}
+
+var errUnknownUpload = errors.New("unknown multipart upload")
+
+func (r *Repository) uploadDir(uploadID string) (string, error) {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return "", errUnknownUpload
+	}
+	return filepath.Join(r.root, multipartDir, uploadID), nil
+}
+
+func (r *Repository) NewMultipart(key string, contentType string, size int64) (string, error) {
+	if _, err := r.path(key); err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(upload{Key: key, ContentType: contentType})
+	if err != nil {
+		return "", err
+	}
+
+	uploadID := uuid.New().String()
+	dir, _ := r.uploadDir(uploadID)
+
+	if err := os.Mkdir(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	tmp, err := r.stage(func(w io.Writer) error {
+		_, err := w.Write(encoded)
+		return err
+	})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+
+	if err := r.commit(tmp, filepath.Join(dir, uploadFile)); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+// openUpload читает описание загрузки и проверяет, что она для key
+func (r *Repository) openUpload(key string, uploadID string) (string, *upload, error) {
+	dir, err := r.uploadDir(uploadID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	encoded, err := os.ReadFile(filepath.Join(dir, uploadFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, errUnknownUpload
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	var u upload
+	if err := json.Unmarshal(encoded, &u); err != nil {
+		return "", nil, err
+	}
+	if u.Key != key {
+		return "", nil, errUnknownUpload
+	}
+
+	return dir, &u, nil
+}
+
+func (r *Repository) PutPart(
+	key string,
+	uploadID string,
+	partNumber int,
+	reader io.Reader,
+	size int64,
+) error {
+
+	if partNumber < 1 {
+		return fmt.Errorf("invalid part number %d", partNumber)
+	}
+
+	dir, _, err := r.openUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := r.stage(func(w io.Writer) error {
+		return copyExact(w, reader, size)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Повторная загрузка части заменяет предыдущую
+	return r.commit(tmp, filepath.Join(dir, partPrefix+strconv.Itoa(partNumber)))
+}
+
+// CompleteMultipart склеивает части по возрастанию номеров
+func (r *Repository) CompleteMultipart(key string, uploadID string) error {
+	dir, u, err := r.openUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	parts, err := listParts(dir)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("multipart upload %s has no parts", uploadID)
+	}
+
+	hasher := md5.New()
+
+	tmp, err := r.stage(func(w io.Writer) error {
+		w = io.MultiWriter(w, hasher)
+		for _, part := range parts {
+			if err := appendFile(w, filepath.Join(dir, partPrefix+strconv.Itoa(part))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.writeObject(tmp, path, objectMeta{
+		ContentType: u.ContentType,
+		ETag:        hex.EncodeToString(hasher.Sum(nil)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		r.logger.Warnf("failed to remove parts of upload %s: %s", uploadID, err)
+	}
+	return nil
+}
+
+func (r *Repository) AbortMultipart(key string, uploadID string) error {
+	dir, _, err := r.openUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// listParts возвращает номера загруженных частей по возрастанию
+func listParts(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []int
+	for _, entry := range entries {
+		number, ok := strings.CutPrefix(entry.Name(), partPrefix)
+		if !ok {
+			continue
+		}
+
+		part, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	slices.Sort(parts)
+	return parts, nil
+}
+
+func appendFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
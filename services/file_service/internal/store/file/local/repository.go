@@ -0,0 +1,172 @@
+package local
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+)
+
+// Каталоги внутри Root: объекты, временные файлы незавершенных записей
+// и части multipart загрузок. Все на одной файловой системе, поэтому
+// перенос из tmp в objects атомарен
+const (
+	objectsDir   = "objects"
+	tmpDir       = "tmp"
+	multipartDir = "multipart"
+)
+
+// Суффикс файла с метаданными объекта, в base64url точки нет,
+// поэтому он не совпадет с именем объекта
+const metaSuffix = ".meta"
+
+// Предел длины имени файла в большинстве файловых систем
+const maxNameLength = 255
+
+// Repository хранит объекты в каталоге на диске. Объект - файл,
+// имя которого - ключ в base64url, рядом лежит JSON с типом
+// содержимого и ETag. Запись идет во временный файл, который
+// переименовывается на место только целиком
+type Repository struct {
+	root   string
+	depth  int
+	logger logger.Logger
+}
+
+// objectMeta - метаданные объекта, которых нет у файла
+type objectMeta struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+var errKeyTooLong = errors.New("object key is too long for local storage")
+
+func New(logger logger.Logger, cfg Config) (*Repository, error) {
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range []string{objectsDir, tmpDir, multipartDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o750); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Repository{
+		root:   root,
+		depth:  cfg.ShardDepth,
+		logger: logger,
+	}, nil
+}
+
+// path возвращает путь файла объекта. Подкаталоги берутся из хеша
+// ключа, поэтому объекты распределяются равномерно при любых ключах
+func (r *Repository) path(key string) (string, error) {
+	name := base64.RawURLEncoding.EncodeToString([]byte(key))
+	if len(name)+len(metaSuffix) > maxNameLength {
+		return "", errKeyTooLong
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	shard := hex.EncodeToString(sum[:r.depth])
+
+	parts := []string{r.root, objectsDir}
+	for i := 0; i < r.depth; i++ {
+		parts = append(parts, shard[2*i:2*i+2])
+	}
+	parts = append(parts, name)
+
+	return filepath.Join(parts...), nil
+}
+
+// keyOf восстанавливает ключ по имени файла объекта
+func keyOf(name string) (string, bool) {
+	key, err := base64.RawURLEncoding.DecodeString(name)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// stage записывает содержимое во временный файл и сбрасывает его на диск.
+// Файл нужно перенести вызовом commit или удалить
+func (r *Repository) stage(write func(w io.Writer) error) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Join(r.root, tmpDir), "stage-*")
+	if err != nil {
+		return "", err
+	}
+
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// commit переносит временный файл на место path
+func (r *Repository) commit(tmp string, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// writeObject записывает метаданные и затем объект из временного файла.
+// Если запись прервется между ними, останутся только метаданные,
+// а объект без файла считается отсутствующим
+func (r *Repository) writeObject(tmp string, path string, meta objectMeta) error {
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	metaTmp, err := r.stage(func(w io.Writer) error {
+		_, err := w.Write(encoded)
+		return err
+	})
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := r.commit(metaTmp, path+metaSuffix); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return r.commit(tmp, path)
+}
+
+func (r *Repository) readMeta(path string) (objectMeta, error) {
+	var meta objectMeta
+
+	encoded, err := os.ReadFile(path + metaSuffix)
+	if err != nil {
+		return meta, err
+	}
+
+	err = json.Unmarshal(encoded, &meta)
+	return meta, err
+}
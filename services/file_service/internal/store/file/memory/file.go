@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+func (r *Repository) Put(
+	key string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) error {
+
+	data, err := readExact(reader, size)
+	if err != nil {
+		return err
+	}
+
+	r.store(key, data, contentType)
+	return nil
+}
+
+// readExact читает ровно size байт, size -1 - до конца reader
+func readExact(reader io.Reader, size int64) ([]byte, error) {
+	if size < 0 {
+		return io.ReadAll(reader)
+	}
+
+	data := make([]byte, size)
+	if n, err := io.ReadFull(reader, data); err != nil {
+		return nil, fmt.Errorf("object is %d bytes, expected %d: %w", n, size, err)
+	}
+	return data, nil
+}
+
+func (r *Repository) store(key string, data []byte, contentType string) {
+	sum := md5.Sum(data)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.objects[key] = &object{
+		data:        data,
+		contentType: contentType,
+		etag:        hex.EncodeToString(sum[:]),
+		modified:    time.Now(),
+	}
+}
+
+func (r *Repository) object(key string) (*object, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	obj, ok := r.objects[key]
+	if !ok {
+		return nil, store.ErrObjectNotFound
+	}
+	return obj, nil
+}
+
+func (r *Repository) Get(key string) (io.ReadCloser, error) {
+	return r.GetRange(key, 0, 0)
+}
+
+// GetRange читает length байт начиная с offset, length <= 0 - до конца объекта
+func (r *Repository) GetRange(key string, offset, length int64) (io.ReadCloser, error) {
+	obj, err := r.object(key)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(len(obj.data))
+	if offset > size {
+		return nil, fmt.Errorf("offset %d is beyond object size %d", offset, size)
+	}
+
+	end := size
+	if length > 0 && offset+length < size {
+		end = offset + length
+	}
+
+	return io.NopCloser(bytes.NewReader(obj.data[offset:end])), nil
+}
+
+func (r *Repository) Delete(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.objects, key)
+	return nil
+}
+
+func (r *Repository) Stat(key string) (*model.StorageObjectInfo, error) {
+	obj, err := r.object(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.info(key), nil
+}
+
+func (obj *object) info(key string) *model.StorageObjectInfo {
+	return &model.StorageObjectInfo{
+		Key:          key,
+		Size:         int64(len(obj.data)),
+		ContentType:  obj.contentType,
+		ETag:         obj.etag,
+		LastModified: obj.modified,
+	}
+}
+
+func (r *Repository) Copy(srcKey string, dstKey string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	obj, ok := r.objects[srcKey]
+	if !ok {
+		return store.ErrObjectNotFound
+	}
+
+	copied := *obj
+	copied.modified = time.Now()
+	r.objects[dstKey] = &copied
+	return nil
+}
+
+func (r *Repository) List(prefix string, startAfter string, limit int) ([]*model.StorageObjectInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []string
+	for key := range r.objects {
+		if strings.HasPrefix(key, prefix) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	infos := make([]*model.StorageObjectInfo, 0, len(keys))
+	for _, key := range keys {
+		infos = append(infos, r.objects[key].info(key))
+	}
+
+	return infos, nil
+}
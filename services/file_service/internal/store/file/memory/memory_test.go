@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger/zap"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/storetest"
+)
+
+func TestConformance(t *testing.T) {
+	repo := New(zap.NewLogger(zap.Config{Dir: t.TempDir()}))
+
+	if err := storetest.Run(repo, repo); err != nil {
+		t.Fatal(err)
+	}
+}
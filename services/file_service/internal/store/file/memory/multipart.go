@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// upload - незавершенная multipart загрузка
+type upload struct {
+	key         string
+	contentType string
+	parts       map[int][]byte
+}
+
+var errUnknownUpload = errors.New("unknown multipart upload")
+
+func (r *Repository) NewMultipart(key string, contentType string, size int64) (string, error) {
+	uploadID := uuid.New().String()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.uploads[uploadID] = &upload{
+		key:         key,
+		contentType: contentType,
+		parts:       make(map[int][]byte),
+	}
+	return uploadID, nil
+}
+
+// upload возвращает загрузку, проверяя, что она для key. Вызывается под r.mu
+func (r *Repository) upload(key string, uploadID string) (*upload, error) {
+	u, ok := r.uploads[uploadID]
+	if !ok || u.key != key {
+		return nil, errUnknownUpload
+	}
+	return u, nil
+}
+
+func (r *Repository) PutPart(
+	key string,
+	uploadID string,
+	partNumber int,
+	reader io.Reader,
+	size int64,
+) error {
+
+	if partNumber < 1 {
+		return fmt.Errorf("invalid part number %d", partNumber)
+	}
+
+	data, err := readExact(reader, size)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, err := r.upload(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	// Повторная загрузка части заменяет предыдущую
+	u.parts[partNumber] = data
+	return nil
+}
+
+// CompleteMultipart склеивает части по возрастанию номеров
+func (r *Repository) CompleteMultipart(key string, uploadID string) error {
+	r.mu.Lock()
+	u, err := r.upload(key, uploadID)
+	if err == nil && len(u.parts) == 0 {
+		err = fmt.Errorf("multipart upload %s has no parts", uploadID)
+	}
+	if err == nil {
+		delete(r.uploads, uploadID)
+	}
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	for _, part := range slices.Sorted(maps.Keys(u.parts)) {
+		data.Write(u.parts[part])
+	}
+
+	r.store(key, data.Bytes(), u.contentType)
+	return nil
+}
+
+func (r *Repository) AbortMultipart(key string, uploadID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.upload(key, uploadID); err != nil {
+		return err
+	}
+
+	delete(r.uploads, uploadID)
+	return nil
+}
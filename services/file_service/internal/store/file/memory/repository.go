@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+)
+
+// Repository хранит объекты в памяти процесса. Содержимое теряется
+// при перезапуске, драйвер нужен для тестов и локальной отладки
+type Repository struct {
+	logger logger.Logger
+
+	mu      sync.RWMutex
+	objects map[string]*object
+	uploads map[string]*upload
+}
+
+// object не меняется после записи: Put заменяет его целиком,
+// поэтому читатели могут держать data без блокировки
+type object struct {
+	data        []byte
+	contentType string
+	etag        string
+	modified    time.Time
+}
+
+func New(logger logger.Logger) *Repository {
+	return &Repository{
+		logger:  logger,
+		objects: make(map[string]*object),
+		uploads: make(map[string]*upload),
+	}
+}
@@ -6,4 +6,9 @@ type Config struct {
 	AccessKey string `validate:"required" yaml:"access_key"`
 	SecretKey string `validate:"required" yaml:"secret_key"`
 	Bucket    string `validate:"required" yaml:"bucket"`
+
+	// Адрес MinIO, доступный клиентам, для presigned ссылок
+	PublicEndpoint string `yaml:"public_endpoint"`
+	PublicSSL      bool   `yaml:"public_ssl"`
+	Region         string `yaml:"region" env-default:"us-east-1"`
 }
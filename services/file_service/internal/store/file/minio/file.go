@@ -5,9 +5,12 @@ import (
 	"io"
 
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
 	"github.com/minio/minio-go/v7"
 )
 
+const streamPartSize = 16 << 20
+
 func (r *Repository) Put(
 	key string,
 	reader io.Reader,
@@ -19,6 +22,12 @@ func (r *Repository) Put(
 		ContentType: contentType,
 	}
 
+	// При неизвестном размере minio буферизует каждую часть целиком,
+	// поэтому ограничиваем размер части
+	if size < 0 {
+		opts.PartSize = streamPartSize
+	}
+
 	_, err := r.db.PutObject(
 		context.Background(),
 		r.bucket,
@@ -39,16 +48,49 @@ func (r *Repository) Get(key string) (io.ReadCloser, error) {
 		minio.GetObjectOptions{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, objectError(err)
 	}
 
 	// ВАЖНО: первая операция чтения нужна,
 	// чтобы MinIO реально выполнил запрос
 	if _, err := obj.Stat(); err != nil {
 		_ = obj.Close()
+		return nil, objectError(err)
+	}
+
+	return obj, nil
+}
+
+// GetRange читает length байт начиная с offset, length <= 0 - до конца объекта
+func (r *Repository) GetRange(key string, offset, length int64) (io.ReadCloser, error) {
+	opts := minio.GetObjectOptions{}
+
+	var err error
+	switch {
+	case length > 0:
+		err = opts.SetRange(offset, offset+length-1)
+	case offset > 0:
+		err = opts.SetRange(offset, 0)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	obj, err := r.db.GetObject(
+		context.Background(),
+		r.bucket,
+		key,
+		opts,
+	)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, objectError(err)
+	}
+
 	return obj, nil
 }
 
@@ -69,12 +111,66 @@ func (r *Repository) Stat(key string) (*model.StorageObjectInfo, error) {
 		minio.StatObjectOptions{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, objectError(err)
 	}
 
 	return &model.StorageObjectInfo{
+		Key:          info.Key,
 		Size:         info.Size,
 		ContentType:  info.ContentType,
+		ETag:         info.ETag,
 		LastModified: info.LastModified,
 	}, nil
 }
+
+// Copy использует ComposeObject: в отличие от CopyObject он копирует
+// объекты больше 5 GiB по частям
+func (r *Repository) Copy(srcKey string, dstKey string) error {
+	_, err := r.db.ComposeObject(
+		context.Background(),
+		minio.CopyDestOptions{Bucket: r.bucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: r.bucket, Object: srcKey},
+	)
+
+	return objectError(err)
+}
+
+func (r *Repository) List(prefix string, startAfter string, limit int) ([]*model.StorageObjectInfo, error) {
+	// Отмена останавливает листинг, когда страница набрана
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objects := r.db.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:     prefix,
+		StartAfter: startAfter,
+		Recursive:  true,
+	})
+
+	infos := make([]*model.StorageObjectInfo, 0, limit)
+	for object := range objects {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+
+		infos = append(infos, &model.StorageObjectInfo{
+			Key:          object.Key,
+			Size:         object.Size,
+			ContentType:  object.ContentType,
+			ETag:         object.ETag,
+			LastModified: object.LastModified,
+		})
+		if len(infos) == limit {
+			break
+		}
+	}
+
+	return infos, nil
+}
+
+// objectError переводит ответ об отсутствии объекта в ErrObjectNotFound
+func objectError(err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return store.ErrObjectNotFound
+	}
+	return err
+}
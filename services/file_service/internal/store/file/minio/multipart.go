@@ -0,0 +1,111 @@
+package minio
+
+import (
+	"context"
+	"io"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/minio/minio-go/v7"
+)
+
+// Максимальное количество частей в ответе ListObjectParts
+const listPartsPage = 1000
+
+type MultipartRepository struct {
+	db     minio.Core
+	bucket string
+	logger logger.Logger
+}
+
+func NewMultipart(db *minio.Client, logger logger.Logger, bucket string) store.MultipartRepository {
+	return &MultipartRepository{
+		db:     minio.Core{Client: db},
+		bucket: bucket,
+		logger: logger,
+	}
+}
+
+func (r *MultipartRepository) NewMultipart(key string, contentType string, size int64) (string, error) {
+	return r.db.NewMultipartUpload(
+		context.Background(),
+		r.bucket,
+		key,
+		minio.PutObjectOptions{ContentType: contentType},
+	)
+}
+
+func (r *MultipartRepository) PutPart(
+	key string,
+	uploadID string,
+	partNumber int,
+	reader io.Reader,
+	size int64,
+) error {
+
+	_, err := r.db.PutObjectPart(
+		context.Background(),
+		r.bucket,
+		key,
+		uploadID,
+		partNumber,
+		reader,
+		size,
+		minio.PutObjectPartOptions{},
+	)
+
+	return err
+}
+
+// CompleteMultipart собирает объект из всех загруженных частей,
+// список частей берется из самого MinIO
+func (r *MultipartRepository) CompleteMultipart(key string, uploadID string) error {
+	var parts []minio.CompletePart
+
+	marker := 0
+	for {
+		result, err := r.db.ListObjectParts(
+			context.Background(),
+			r.bucket,
+			key,
+			uploadID,
+			marker,
+			listPartsPage,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, part := range result.ObjectParts {
+			parts = append(parts, minio.CompletePart{
+				PartNumber: part.PartNumber,
+				ETag:       part.ETag,
+			})
+		}
+
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextPartNumberMarker
+	}
+
+	_, err := r.db.CompleteMultipartUpload(
+		context.Background(),
+		r.bucket,
+		key,
+		uploadID,
+		parts,
+		minio.PutObjectOptions{},
+	)
+
+	return err
+}
+
+func (r *MultipartRepository) AbortMultipart(key string, uploadID string) error {
+	return r.db.AbortMultipartUpload(
+		context.Background(),
+		r.bucket,
+		key,
+		uploadID,
+	)
+}
@@ -0,0 +1,80 @@
+package minio
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/minio/minio-go/v7"
+)
+
+type PresignRepository struct {
+	db     *minio.Client
+	bucket string
+	logger logger.Logger
+}
+
+// NewPresign принимает клиент, настроенный на публичный адрес MinIO:
+// хост входит в подпись, поэтому внутренний адрес клиентам не подойдет
+func NewPresign(db *minio.Client, logger logger.Logger, bucket string) store.PresignRepository {
+	return &PresignRepository{
+		db:     db,
+		bucket: bucket,
+		logger: logger,
+	}
+}
+
+func (r *PresignRepository) PresignPut(
+	key string,
+	contentType string,
+	expiry time.Duration,
+) (string, http.Header, error) {
+
+	headers := http.Header{}
+	if contentType != "" {
+		headers.Set("Content-Type", contentType)
+	}
+
+	u, err := r.db.PresignHeader(
+		context.Background(),
+		http.MethodPut,
+		r.bucket,
+		key,
+		expiry,
+		nil,
+		headers,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return u.String(), headers, nil
+}
+
+func (r *PresignRepository) PresignGet(
+	key string,
+	contentDisposition string,
+	expiry time.Duration,
+) (string, error) {
+
+	params := url.Values{}
+	if contentDisposition != "" {
+		params.Set("response-content-disposition", contentDisposition)
+	}
+
+	u, err := r.db.PresignedGetObject(
+		context.Background(),
+		r.bucket,
+		key,
+		expiry,
+		params,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
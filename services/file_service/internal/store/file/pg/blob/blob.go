@@ -0,0 +1,142 @@
+package blob
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) Link(hash string, crc32c string, contentKey string, size int64, put func(key string) error) (*model.Blob, error) {
+	blob := &model.Blob{}
+	storageKey := fmt.Sprintf("%s/%s", contentKey, uuid.New())
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Upsert блокирует строку до конца транзакции: параллельный Link
+		// того же blob дождётся, пока объект будет записан.
+		// CRC32C дополняет blob, сохраненный без нее
+		result := tx.Raw(`
+			INSERT INTO blobs (hash, crc32c, content_key, storage_key, size, ref_count, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, 1, now(), now())
+			ON CONFLICT (content_key) DO UPDATE
+			SET ref_count = blobs.ref_count + 1, updated_at = now(),
+				crc32c = CASE WHEN blobs.crc32c = '' THEN EXCLUDED.crc32c ELSE blobs.crc32c END
+			RETURNING *`,
+			hash, crc32c, contentKey, storageKey, size,
+		).Scan(blob)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if blob.RefCount > 1 && !blob.Missing {
+			return nil
+		}
+
+		if err := put(blob.StorageKey); err != nil {
+			return err
+		}
+
+		if !blob.Missing {
+			return nil
+		}
+
+		// Содержимое загружено заново: снимаем отметку сверки
+		blob.Missing = false
+		if err := tx.Model(blob).Update("missing", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE files SET content_missing = false WHERE storage_key = ?", blob.StorageKey).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE file_versions SET content_missing = false WHERE storage_key = ?", blob.StorageKey).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+func (r *Repository) Unlink(key string, remove func(key string) error) error {
+	var removed bool
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		blob := &model.Blob{}
+
+		result := tx.Raw(`
+			UPDATE blobs
+			SET ref_count = ref_count - 1, updated_at = now()
+			WHERE storage_key = ?
+			RETURNING *`,
+			key,
+		).Scan(blob)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if blob.RefCount > 0 {
+			return nil
+		}
+
+		if err := tx.Delete(&model.Blob{}, blob.ID).Error; err != nil {
+			return err
+		}
+
+		removed = true
+		return nil
+	})
+	if err != nil || !removed {
+		return err
+	}
+
+	// Объект удаляется вне транзакции, чтобы строка не оставалась
+	// заблокированной на время запроса к хранилищу
+	return remove(key)
+}
+
+func (r *Repository) ListUnverified(before time.Time, limit int) ([]*model.Blob, error) {
+	var blobs []*model.Blob
+
+	err := r.db.
+		Where("NOT missing AND (verified_at IS NULL OR verified_at < ?)", before).
+		Order("verified_at NULLS FIRST, id").
+		Limit(limit).
+		Find(&blobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+func (r *Repository) MarkVerified(blob *model.Blob, crc32c string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Blob{}).
+			Where("id = ?", blob.ID).
+			Updates(map[string]any{"verified_at": time.Now(), "crc32c": crc32c}).Error
+		if err != nil {
+			return err
+		}
+
+		if blob.CRC32C != "" || crc32c == "" {
+			return nil
+		}
+
+		// Сумма посчитана впервые: дописываем ее файлам и версиям
+		err = tx.Unscoped().Model(&model.File{}).
+			Where("storage_key = ? AND crc32c = ''", blob.StorageKey).
+			Update("crc32c", crc32c).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.FileVersion{}).
+			Where("storage_key = ? AND crc32c = ''", blob.StorageKey).
+			Update("crc32c", crc32c).Error
+	})
+}
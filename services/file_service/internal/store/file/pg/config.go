@@ -7,3 +7,9 @@ type Config struct {
 	Password     string `validate:"required" yaml:"password"`
 	DatabaseName string `validate:"required" yaml:"database"`
 }
+
+// QuotaConfig - лимиты занятого объема в байтах, 0 - без ограничения
+type QuotaConfig struct {
+	UserLimit   int64 `yaml:"user_limit" env-default:"1073741824"`
+	CourseLimit int64 `yaml:"course_limit" env-default:"10737418240"`
+}
@@ -0,0 +1,84 @@
+package datakey
+
+import (
+	"errors"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) GetOrCreate(courseID string, create func() (*model.DataKey, error)) (*model.DataKey, error) {
+	key := &model.DataKey{}
+
+	err := r.db.Where("course_id = ?", courseID).First(key).Error
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	key, err = create()
+	if err != nil {
+		return nil, err
+	}
+	key.CourseID = courseID
+
+	// Ключ, созданный параллельно, побеждает: содержимое уже могло
+	// быть зашифровано им
+	err = r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "course_id"}},
+		DoNothing: true,
+	}).Create(key).Error
+	if err != nil {
+		return nil, err
+	}
+
+	key = &model.DataKey{}
+	if err := r.db.Where("course_id = ?", courseID).First(key).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *Repository) GetByID(id uint64) (*model.DataKey, error) {
+	key := &model.DataKey{}
+
+	result := r.db.First(key, id)
+	if result.Error != nil {
+		return &model.DataKey{}, result.Error
+	}
+	return key, nil
+}
+
+func (r *Repository) ListNotWrappedBy(masterKeyID string, afterID uint64, limit int) ([]*model.DataKey, error) {
+	var keys []*model.DataKey
+
+	result := r.db.
+		Where("master_key_id <> ? AND id > ?", masterKeyID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&keys)
+	if result.Error != nil {
+		return []*model.DataKey{}, result.Error
+	}
+	return keys, nil
+}
+
+func (r *Repository) Rewrap(key *model.DataKey, oldMasterKeyID string) error {
+	result := r.db.Model(&model.DataKey{}).
+		Where("id = ? AND master_key_id = ?", key.ID, oldMasterKeyID).
+		Updates(map[string]any{
+			"wrapped":       key.Wrapped,
+			"master_key_id": key.MasterKeyID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package datakey
+
+import (
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db     *gorm.DB
+	logger logger.Logger
+}
+
+func New(db *gorm.DB, logger logger.Logger) store.KeyRepository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
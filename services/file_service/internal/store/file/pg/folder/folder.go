@@ -0,0 +1,150 @@
+package folder
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// subtreeQuery выбирает UUID папки и всех ее потомков
+const subtreeQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT uuid FROM folders WHERE uuid = ?
+		UNION
+		SELECT f.uuid FROM folders f JOIN subtree s ON f.parent_id = s.uuid
+	)
+	SELECT uuid FROM subtree`
+
+func (r *Repository) Create(folder *model.Folder) error {
+	return r.db.Create(folder).Error
+}
+
+func (r *Repository) GetByID(id string) (*model.Folder, error) {
+	folder := &model.Folder{}
+
+	result := r.db.Where("uuid = ?", id).First(folder)
+	if result.Error != nil {
+		return &model.Folder{}, result.Error
+	}
+	return folder, nil
+}
+
+func (r *Repository) Rename(id string, name string) error {
+	result := r.db.Model(&model.Folder{}).Where("uuid = ?", id).Update("name", name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository) Move(id string, parentID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if parentID != "" {
+			var subtree []string
+			if err := tx.Raw(subtreeQuery, id).Scan(&subtree).Error; err != nil {
+				return err
+			}
+
+			for _, uuid := range subtree {
+				if uuid == parentID {
+					return store.ErrFolderCycle
+				}
+			}
+		}
+
+		result := tx.Model(&model.Folder{}).Where("uuid = ?", id).Update("parent_id", parentID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Delete(id string) (int64, error) {
+	var filesDeleted int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var subtree []string
+		if err := tx.Raw(subtreeQuery, id).Scan(&subtree).Error; err != nil {
+			return err
+		}
+		if len(subtree) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Файлы уходят в корзину с прежним folder_id,
+		// при восстановлении он сбрасывается, если папки уже нет
+		var files []*model.File
+		result := tx.Model(&files).
+			Clauses(clause.Returning{}).
+			Where("folder_id IN ?", subtree).
+			Update("deleted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		filesDeleted = result.RowsAffected
+
+		if err := outbox.Emit(tx, model.EventFileDeleted, files...); err != nil {
+			return err
+		}
+
+		return tx.Where("uuid IN ?", subtree).Delete(&model.Folder{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return filesDeleted, nil
+}
+
+func (r *Repository) Subtree(id string) ([]*model.Folder, error) {
+	var subtree []string
+	if err := r.db.Raw(subtreeQuery, id).Scan(&subtree).Error; err != nil {
+		return []*model.Folder{}, err
+	}
+
+	var folders []*model.Folder
+
+	result := r.db.Where("uuid IN ?", subtree).Find(&folders)
+	if result.Error != nil {
+		return []*model.Folder{}, result.Error
+	}
+	return folders, nil
+}
+
+func (r *Repository) ListChildren(
+	parentID string,
+	courseID string,
+	groupID string,
+	creatorID string,
+) ([]*model.Folder, error) {
+
+	var folders []*model.Folder
+
+	db := r.db.Where("parent_id = ?", parentID)
+
+	// У корня родителя нет, папки выбираются по курсу и группе
+	if parentID == "" {
+		db = db.Where("course_id = ? AND group_id = ?", courseID, groupID)
+
+		if courseID == "" && groupID == "" {
+			db = db.Where("creator_id = ?", creatorID)
+		}
+	}
+
+	result := db.Order("name").Find(&folders)
+	if result.Error != nil {
+		return []*model.Folder{}, result.Error
+	}
+	return folders, nil
+}
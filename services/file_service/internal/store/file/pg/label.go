@@ -0,0 +1,153 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) AddTags(fileID string, tags []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveTags(tx, fileID, tags); err != nil {
+			return err
+		}
+		if err := indexFiles(tx, "f.uuid = ?", fileID); err != nil {
+			return err
+		}
+		return emitUpdated(tx, fileID)
+	})
+}
+
+func (r *Repository) RemoveTags(fileID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("file_uuid = ? AND tag IN ?", fileID, tags).Delete(&model.FileTag{}).Error
+		if err != nil {
+			return err
+		}
+		if err := indexFiles(tx, "f.uuid = ?", fileID); err != nil {
+			return err
+		}
+		return emitUpdated(tx, fileID)
+	})
+}
+
+func (r *Repository) SetMetadata(fileID string, metadata map[string]string, replace bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			keys := make([]string, 0, len(metadata))
+			for key := range metadata {
+				keys = append(keys, key)
+			}
+
+			db := tx.Where("file_uuid = ?", fileID)
+			if len(keys) > 0 {
+				db = db.Where("key NOT IN ?", keys)
+			}
+			if err := db.Delete(&model.FileAttribute{}).Error; err != nil {
+				return err
+			}
+		}
+
+		var removed []string
+		for key, value := range metadata {
+			if value == "" {
+				removed = append(removed, key)
+			}
+		}
+		if len(removed) > 0 {
+			err := tx.Where("file_uuid = ? AND key IN ?", fileID, removed).Delete(&model.FileAttribute{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := saveAttributes(tx, fileID, metadata); err != nil {
+			return err
+		}
+		return emitUpdated(tx, fileID)
+	})
+}
+
+// emitUpdated записывает событие об изменении тегов или метаданных файла
+func emitUpdated(tx *gorm.DB, fileID string) error {
+	file := &model.File{}
+	if err := tx.Where("uuid = ?", fileID).First(file).Error; err != nil {
+		return err
+	}
+	return outbox.Emit(tx, model.EventFileUpdated, file)
+}
+
+// saveTags добавляет теги файлу, уже добавленные пропускаются
+func saveTags(tx *gorm.DB, fileID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]model.FileTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, model.FileTag{FileUUID: fileID, Tag: tag})
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// saveAttributes записывает непустые пары метаданных поверх существующих
+func saveAttributes(tx *gorm.DB, fileID string, metadata map[string]string) error {
+	rows := make([]model.FileAttribute, 0, len(metadata))
+	for key, value := range metadata {
+		if value != "" {
+			rows = append(rows, model.FileAttribute{FileUUID: fileID, Key: key, Value: value})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "file_uuid"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value"}),
+	}).Create(&rows).Error
+}
+
+// labelFiles заполняет Tags и Metadata файлов двумя запросами на всю выборку
+func labelFiles(db *gorm.DB, files ...*model.File) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*model.File, len(files))
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		file.Tags = []string{}
+		file.Metadata = map[string]string{}
+
+		byID[file.UUID] = file
+		ids = append(ids, file.UUID)
+	}
+
+	var tags []model.FileTag
+	if err := db.Where("file_uuid IN ?", ids).Order("tag").Find(&tags).Error; err != nil {
+		return err
+	}
+	for _, t := range tags {
+		if file, ok := byID[t.FileUUID]; ok {
+			file.Tags = append(file.Tags, t.Tag)
+		}
+	}
+
+	var attributes []model.FileAttribute
+	if err := db.Where("file_uuid IN ?", ids).Find(&attributes).Error; err != nil {
+		return err
+	}
+	for _, a := range attributes {
+		if file, ok := byID[a.FileUUID]; ok {
+			file.Metadata[a.Key] = a.Value
+		}
+	}
+	return nil
+}
@@ -2,10 +2,50 @@ package pg
 
 import (
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Create сохраняет файл вместе с его первой версией
 func (r *Repository) Create(file *model.File) error {
-	return r.db.Create(&file).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		file.CurrentVersion = 1
+		if file.ScanStatus == "" {
+			file.ScanStatus = model.ScanPending
+		}
+		if file.PreviewState == "" {
+			file.PreviewState = model.PreviewPending
+		}
+		if file.TextState == "" {
+			file.TextState = model.TextPending
+		}
+
+		if err := tx.Create(&file).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(versionOf(file, 1)).Error; err != nil {
+			return err
+		}
+
+		if err := saveTags(tx, file.UUID, file.Tags); err != nil {
+			return err
+		}
+		if err := saveAttributes(tx, file.UUID, file.Metadata); err != nil {
+			return err
+		}
+
+		if err := indexFiles(tx, "f.uuid = ?", file.UUID); err != nil {
+			return err
+		}
+
+		if err := outbox.Emit(tx, model.EventFileUploaded, file); err != nil {
+			return err
+		}
+
+		return r.charge(tx, file.UploaderID, file.CourseID, file.Size)
+	})
 }
 
 func (r *Repository) GetByID(id string) (*model.File, error) {
@@ -15,45 +55,218 @@ func (r *Repository) GetByID(id string) (*model.File, error) {
 	if result.Error != nil {
 		return &model.File{}, result.Error
 	}
+
+	if err := labelFiles(r.db, file); err != nil {
+		return &model.File{}, err
+	}
 	return file, nil
 }
 
 func (r *Repository) Delete(id string) error {
-	result := r.db.Where("uuid = ?", id).Delete(model.File{})
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		file := &model.File{}
+		if err := lockFile(tx, id, file); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(file).Error; err != nil {
+			return err
+		}
+
+		return outbox.Emit(tx, model.EventFileDeleted, file)
+	})
 }
 
-func (r *Repository) ListByCourse(courseID string) ([]*model.File, error) {
-	var f []model.File
+func (r *Repository) List(query model.FileQuery) ([]*model.File, int64, error) {
+	db := r.db.Model(&model.File{})
 
-	result := r.db.Where("course_id = ?", courseID).Find(&f)
-	if result.Error != nil {
-		return []*model.File{}, result.Error
+	if query.Trashed {
+		db = db.Unscoped().Where("deleted_at IS NOT NULL")
 	}
 
-	files := make([]*model.File, 0)
+	if query.UploaderID != "" {
+		db = db.Where("uploader_id = ?", query.UploaderID)
+	}
+	if query.CourseID != "" {
+		db = db.Where("course_id = ?", query.CourseID)
+	}
+	if query.GroupID != "" {
+		db = db.Where("group_id = ?", query.GroupID)
+	}
+	if query.ByFolder {
+		db = db.Where("folder_id = ?", query.FolderID)
 
-	for _, file := range f {
-		files = append(files, &file)
+		// Корень определяется курсом и группой целиком, включая пустые
+		if query.FolderID == "" {
+			db = db.Where("course_id = ? AND group_id = ?", query.CourseID, query.GroupID)
+		}
+	}
+	if len(query.FolderIDs) > 0 {
+		db = db.Where("folder_id IN ?", query.FolderIDs)
+	}
+	if query.MimeType != "" {
+		db = db.Where("mime_type = ?", query.MimeType)
+	}
+	if !query.CreatedFrom.IsZero() {
+		db = db.Where("created_at >= ?", query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		db = db.Where("created_at < ?", query.CreatedTo)
+	}
+	for _, tag := range query.Tags {
+		db = db.Where("EXISTS (SELECT 1 FROM file_tags t WHERE t.file_uuid = files.uuid AND t.tag = ?)", tag)
+	}
+	for key, value := range query.Metadata {
+		db = db.Where(
+			"EXISTS (SELECT 1 FROM file_attributes a WHERE a.file_uuid = files.uuid AND a.key = ? AND a.value = ?)",
+			key, value,
+		)
 	}
-	return files, nil
-}
 
-func (r *Repository) ListByGroup(groupID string) ([]*model.File, error) {
+	// Запрос переиспользуется для подсчёта и выборки страницы
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []*model.File{}, 0, err
+	}
+
+	// id добавлен для стабильного порядка при равных значениях
+	order := clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: sortColumn(query.SortBy)}, Desc: query.Desc},
+		{Column: clause.Column{Name: "id"}, Desc: query.Desc},
+	}}
+
 	var f []model.File
 
-	result := r.db.Where("group_id = ?", groupID).Find(&f)
+	result := db.Order(order).Offset(query.Offset).Limit(query.Limit).Find(&f)
 	if result.Error != nil {
-		return []*model.File{}, result.Error
+		return []*model.File{}, 0, result.Error
 	}
 
-	files := make([]*model.File, 0)
+	files := make([]*model.File, 0, len(f))
 
 	for _, file := range f {
 		files = append(files, &file)
 	}
-	return files, nil
+
+	if err := labelFiles(r.db, files...); err != nil {
+		return []*model.File{}, 0, err
+	}
+	return files, total, nil
+}
+
+func sortColumn(field model.FileSortField) string {
+	switch field {
+	case model.SortByName:
+		return "name"
+	case model.SortBySize:
+		return "size"
+	default:
+		return "created_at"
+	}
+}
+
+func (r *Repository) UpdateMetadata(id string, name string, mimeType string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		file := &model.File{}
+		if err := lockFile(tx, id, file); err != nil {
+			return err
+		}
+
+		updates := map[string]any{}
+		if name != "" {
+			updates["name"] = name
+		}
+		if mimeType != "" {
+			updates["mime_type"] = mimeType
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(file).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if name != "" {
+			file.Name = name
+			if err := indexFiles(tx, "f.uuid = ?", id); err != nil {
+				return err
+			}
+		}
+		if mimeType != "" {
+			file.MimeType = mimeType
+		}
+
+		if err := outbox.Emit(tx, model.EventFileUpdated, file); err != nil {
+			return err
+		}
+
+		// Тип относится к содержимому, поэтому меняется и у текущей версии
+		if mimeType == "" {
+			return nil
+		}
+		return tx.Model(&model.FileVersion{}).
+			Where("file_uuid = ? AND version = ?", id, file.CurrentVersion).
+			Update("mime_type", mimeType).Error
+	})
+}
+
+func (r *Repository) Move(id string, courseID string, groupID string, folderID string, keys map[string]string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		file := &model.File{}
+		if err := lockFile(tx, id, file); err != nil {
+			return err
+		}
+
+		if file.CourseID != courseID {
+			versions, err := fileVersions(tx, file)
+			if err != nil {
+				return err
+			}
+
+			var size int64
+			for _, v := range versions {
+				size += v.Size
+			}
+
+			if file.CourseID != "" {
+				if err := r.chargeOwner(tx, model.UsageScopeCourse, file.CourseID, -size); err != nil {
+					return err
+				}
+			}
+			if courseID != "" {
+				if err := r.chargeOwner(tx, model.UsageScopeCourse, courseID, size); err != nil {
+					return err
+				}
+			}
+		}
+
+		for oldKey, newKey := range keys {
+			err := tx.Model(&model.FileVersion{}).
+				Where("file_uuid = ? AND storage_key = ?", id, oldKey).
+				Update("storage_key", newKey).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]any{
+			"course_id": courseID,
+			"group_id":  groupID,
+			"folder_id": folderID,
+		}
+		if newKey, ok := keys[file.StorageKey]; ok {
+			updates["storage_key"] = newKey
+			file.StorageKey = newKey
+		}
+
+		if err := tx.Model(file).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		file.CourseID, file.GroupID, file.FolderID = courseID, groupID, folderID
+		return outbox.Emit(tx, model.EventFileMoved, file)
+	})
 }
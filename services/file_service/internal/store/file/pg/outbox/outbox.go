@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ключ advisory-блокировки relay, значение произвольное,
+// но общее для всех реплик
+const relayLockKey = 7_401_001
+
+// Emit записывает в outbox событие о каждом из files. Вызывается
+// в транзакции изменения, поэтому событие появляется ровно тогда,
+// когда изменение зафиксировано
+func Emit(tx *gorm.DB, eventType string, files ...*model.File) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	events := make([]*model.OutboxEvent, 0, len(files))
+
+	for _, file := range files {
+		id := uuid.New().String()
+
+		payload, err := json.Marshal(model.FileEvent{
+			ID:         id,
+			Type:       eventType,
+			OccurredAt: now,
+			FileID:     file.UUID,
+			Name:       file.Name,
+			MimeType:   file.MimeType,
+			Size:       file.Size,
+			Version:    file.CurrentVersion,
+			Sha256:     file.Hash,
+			CRC32C:     file.CRC32C,
+			UploaderID: file.UploaderID,
+			CourseID:   file.CourseID,
+			GroupID:    file.GroupID,
+			FolderID:   file.FolderID,
+			ScanStatus: file.ScanStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		events = append(events, &model.OutboxEvent{
+			UUID:      id,
+			Type:      eventType,
+			FileUUID:  file.UUID,
+			Payload:   payload,
+			CreatedAt: now,
+		})
+	}
+
+	return tx.Create(&events).Error
+}
+
+func (r *Repository) Relay(limit int, publish func(events []*model.OutboxEvent) (int, error)) error {
+	var publishErr error
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+
+		// События уже публикует другая реплика
+		if !locked {
+			return nil
+		}
+
+		var events []*model.OutboxEvent
+		err := tx.Where("published_at IS NULL").Order("id").Limit(limit).Find(&events).Error
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		var published int
+		published, publishErr = publish(events)
+
+		if published > 0 {
+			ids := make([]uint64, 0, published)
+			for _, event := range events[:published] {
+				ids = append(ids, event.ID)
+			}
+
+			err := tx.Model(&model.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if publishErr != nil && published < len(events) {
+			return tx.Model(events[published]).Updates(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": publishErr.Error(),
+			}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return publishErr
+}
+
+func (r *Repository) DeletePublished(before time.Time, limit int) (int64, error) {
+	result := r.db.Exec(`
+		DELETE FROM outbox_events WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE published_at IS NOT NULL AND published_at < ?
+			ORDER BY id
+			LIMIT ?
+		)`,
+		before, limit,
+	)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
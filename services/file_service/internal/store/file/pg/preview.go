@@ -0,0 +1,27 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+)
+
+func (r *Repository) ListPendingPreview(limit int) ([]*model.File, error) {
+	var files []*model.File
+
+	result := r.db.Raw(`
+		SELECT DISTINCT ON (storage_key) * FROM files
+		WHERE preview_state = ? AND scan_status = ? AND NOT content_missing
+		ORDER BY storage_key
+		LIMIT ?`,
+		model.PreviewPending, model.ScanClean, limit,
+	).Scan(&files)
+	if result.Error != nil {
+		return []*model.File{}, result.Error
+	}
+	return files, nil
+}
+
+func (r *Repository) SetPreviewState(storageKey string, state string) error {
+	return r.db.Unscoped().Model(&model.File{}).
+		Where("storage_key = ?", storageKey).
+		Update("preview_state", state).Error
+}
@@ -0,0 +1,86 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) ReferencedKeys(keys []string) (map[string]bool, error) {
+	referenced := make(map[string]bool, len(keys))
+	if len(keys) == 0 {
+		return referenced, nil
+	}
+
+	var found []string
+	result := r.db.Raw(`
+		SELECT storage_key FROM files WHERE storage_key IN @keys
+		UNION
+		SELECT storage_key FROM file_versions WHERE storage_key IN @keys
+		UNION
+		SELECT storage_key FROM blobs WHERE storage_key IN @keys
+		UNION
+		SELECT storage_key FROM upload_sessions WHERE storage_key IN @keys AND deleted_at IS NULL`,
+		map[string]any{"keys": keys},
+	).Scan(&found)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, key := range found {
+		referenced[key] = true
+	}
+	return referenced, nil
+}
+
+func (r *Repository) ListStorageKeys(after string, limit int) ([]string, error) {
+	// Порядок байтов совпадает с порядком листинга хранилища
+	// и не зависит от локали базы
+	var keys []string
+	result := r.db.Raw(`
+		SELECT storage_key FROM (
+			SELECT storage_key FROM files WHERE NOT content_missing
+			UNION
+			SELECT storage_key FROM file_versions WHERE NOT content_missing
+		) stored
+		WHERE storage_key COLLATE "C" > ?
+		ORDER BY storage_key COLLATE "C"
+		LIMIT ?`,
+		after, limit,
+	).Scan(&keys)
+	if result.Error != nil {
+		return []string{}, result.Error
+	}
+	return keys, nil
+}
+
+func (r *Repository) MarkContentMissing(storageKey string) (int64, error) {
+	var marked int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.File{}).
+			Where("storage_key = ? AND NOT content_missing", storageKey).
+			Update("content_missing", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		marked += result.RowsAffected
+
+		result = tx.Model(&model.FileVersion{}).
+			Where("storage_key = ? AND NOT content_missing", storageKey).
+			Update("content_missing", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		marked += result.RowsAffected
+
+		// Следующая загрузка того же содержимого запишет объект заново
+		return tx.Model(&model.Blob{}).
+			Where("storage_key = ?", storageKey).
+			Update("missing", true).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return marked, nil
+}
@@ -14,11 +14,13 @@ const delay = 2 * time.Second
 type Repository struct {
 	db     *gorm.DB
 	logger logger.Logger
+	quota  QuotaConfig
 }
 
-func New(db *gorm.DB, logger logger.Logger) store.MetaRepository {
+func New(db *gorm.DB, logger logger.Logger, quota QuotaConfig) store.MetaRepository {
 	return &Repository{
 		db:     db,
 		logger: logger,
+		quota:  quota,
 	}
 }
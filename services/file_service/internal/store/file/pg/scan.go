@@ -0,0 +1,57 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) ListPendingScan(limit int) ([]*model.ScanTarget, error) {
+	var targets []*model.ScanTarget
+
+	result := r.db.Raw(`
+		SELECT storage_key, MAX(size) AS size FROM (
+			SELECT storage_key, size FROM files
+			WHERE scan_status = @pending AND NOT content_missing
+			UNION ALL
+			SELECT storage_key, size FROM file_versions
+			WHERE scan_status = @pending AND NOT content_missing
+		) pending
+		GROUP BY storage_key
+		LIMIT @limit`,
+		map[string]any{"pending": model.ScanPending, "limit": limit},
+	).Scan(&targets)
+	if result.Error != nil {
+		return []*model.ScanTarget{}, result.Error
+	}
+	return targets, nil
+}
+
+func (r *Repository) SetScanResult(storageKey string, scan model.ScanResult) error {
+	values := map[string]any{
+		"scan_status":    scan.ScanStatus,
+		"scan_signature": scan.ScanSignature,
+		"scanned_at":     scan.ScannedAt,
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var files []*model.File
+		err := tx.Unscoped().Model(&files).
+			Clauses(clause.Returning{}).
+			Where("storage_key = ? AND scan_status = ?", storageKey, model.ScanPending).
+			Updates(values).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.FileVersion{}).
+			Where("storage_key = ? AND scan_status = ?", storageKey, model.ScanPending).
+			Updates(values).Error
+		if err != nil {
+			return err
+		}
+
+		return outbox.Emit(tx, model.EventFileScanned, files...)
+	})
+}
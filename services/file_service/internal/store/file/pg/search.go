@@ -0,0 +1,154 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Конфигурация полнотекстового поиска. simple не делает стемминг, зато
+// одинаково работает с русским и английским текстом
+const searchConfig = "simple"
+
+// Параметры ts_headline, совпадения обрамляются управляющими символами,
+// чтобы текст можно было экранировать до подстановки разметки
+const (
+	nameHeadline    = "HighlightAll=true, StartSel=" + model.HighlightStart + ", StopSel=" + model.HighlightStop
+	snippetHeadline = "MaxFragments=2, MaxWords=25, MinWords=8, StartSel=" + model.HighlightStart + ", StopSel=" + model.HighlightStop
+)
+
+func (r *Repository) Search(query model.SearchQuery) ([]*model.SearchHit, int64, error) {
+	db := r.db.Table("files f").
+		Joins("JOIN search_documents d ON d.file_uuid = f.uuid").
+		Joins("CROSS JOIN websearch_to_tsquery(?, ?) q", searchConfig, query.Text).
+		Where("f.deleted_at IS NULL AND d.document @@ q")
+
+	if !query.AllAccess {
+		db = db.Where("(f.uploader_id = ? OR f.course_id IN ? OR f.group_id IN ?)",
+			query.UserID, query.CourseIDs, query.GroupIDs)
+	}
+
+	if query.CourseID != "" {
+		db = db.Where("f.course_id = ?", query.CourseID)
+	}
+	if query.GroupID != "" {
+		db = db.Where("f.group_id = ?", query.GroupID)
+	}
+	if query.MimeType != "" {
+		db = db.Where("f.mime_type = ?", query.MimeType)
+	}
+
+	// Запрос переиспользуется для подсчёта и выборки страницы
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []*model.SearchHit{}, 0, err
+	}
+
+	var hits []*model.SearchHit
+	result := db.
+		Joins("LEFT JOIN file_texts t ON t.storage_key = f.storage_key").
+		Select(`f.*,
+			ts_rank_cd(d.document, q) AS rank,
+			ts_headline(?, f.name, q, ?) AS name_highlight,
+			ts_headline(?, COALESCE(t.content, ''), q, ?) AS snippet`,
+			searchConfig, nameHeadline, searchConfig, snippetHeadline,
+		).
+		Order("rank DESC, f.id DESC").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Scan(&hits)
+	if result.Error != nil {
+		return []*model.SearchHit{}, 0, result.Error
+	}
+
+	files := make([]*model.File, 0, len(hits))
+	for _, hit := range hits {
+		files = append(files, &hit.File)
+	}
+	if err := labelFiles(r.db, files...); err != nil {
+		return []*model.SearchHit{}, 0, err
+	}
+
+	return hits, total, nil
+}
+
+func (r *Repository) ListPendingText(limit int) ([]*model.File, error) {
+	var files []*model.File
+
+	result := r.db.Raw(`
+		SELECT DISTINCT ON (storage_key) * FROM files
+		WHERE text_state = ? AND scan_status = ? AND NOT content_missing
+		ORDER BY storage_key
+		LIMIT ?`,
+		model.TextPending, model.ScanClean, limit,
+	).Scan(&files)
+	if result.Error != nil {
+		return []*model.File{}, result.Error
+	}
+	return files, nil
+}
+
+func (r *Repository) SetExtractedText(storageKey string, text string, state string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if state == model.TextReady {
+			err := tx.Clauses(clause.OnConflict{UpdateAll: true}).
+				Create(&model.FileText{StorageKey: storageKey, Content: text}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tx.Unscoped().Model(&model.File{}).
+			Where("storage_key = ?", storageKey).
+			Update("text_state", state).Error
+		if err != nil {
+			return err
+		}
+
+		return indexFiles(tx, "f.storage_key = ?", storageKey)
+	})
+}
+
+// indexFiles пересобирает поисковые документы файлов, подходящих под where.
+// Имя индексируется как есть и с пунктуацией, замененной пробелами,
+// чтобы "lecture_01.pdf" находился по "lecture"; теги весят меньше имени,
+// но больше содержимого
+func indexFiles(tx *gorm.DB, where string, args ...any) error {
+	values := append([]any{searchConfig, searchConfig, searchConfig}, args...)
+
+	return tx.Exec(`
+		INSERT INTO search_documents (file_uuid, document)
+		SELECT f.uuid,
+			setweight(to_tsvector(?, f.name || ' ' || regexp_replace(f.name, '[[:punct:]]+', ' ', 'g')), 'A') ||
+			setweight(to_tsvector(?, COALESCE((SELECT string_agg(tag, ' ') FROM file_tags WHERE file_uuid = f.uuid), '')), 'B') ||
+			setweight(to_tsvector(?, COALESCE(t.content, '')), 'C')
+		FROM files f
+		LEFT JOIN file_texts t ON t.storage_key = f.storage_key
+		WHERE `+where+`
+		ON CONFLICT (file_uuid) DO UPDATE SET document = EXCLUDED.document`,
+		values...,
+	).Error
+}
+
+// dropOrphanTexts удаляет тексты содержимого versions, на которое
+// больше не ссылаются ни файлы, ни версии
+func dropOrphanTexts(tx *gorm.DB, versions []*model.FileVersion) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(versions))
+	for _, v := range versions {
+		keys = append(keys, v.StorageKey)
+	}
+
+	return tx.Exec(`
+		DELETE FROM file_texts t
+		WHERE t.storage_key IN ?
+			AND NOT EXISTS (SELECT 1 FROM files f WHERE f.storage_key = t.storage_key)
+			AND NOT EXISTS (SELECT 1 FROM file_versions v WHERE v.storage_key = t.storage_key)`,
+		keys,
+	).Error
+}
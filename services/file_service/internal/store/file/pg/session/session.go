@@ -0,0 +1,50 @@
+package session
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+)
+
+func (r *Repository) Create(session *model.UploadSession) error {
+	return r.db.Create(session).Error
+}
+
+func (r *Repository) GetByID(id string) (*model.UploadSession, error) {
+	session := &model.UploadSession{}
+
+	result := r.db.Where("uuid = ?", id).First(session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return session, nil
+}
+
+func (r *Repository) Advance(id string, from int64, to int64) error {
+	result := r.db.Model(&model.UploadSession{}).
+		Where("uuid = ? AND \"offset\" = ?", id, from).
+		Update("offset", to)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrConflict
+	}
+	return nil
+}
+
+func (r *Repository) Delete(id string) error {
+	return r.db.Unscoped().Where("uuid = ?", id).Delete(&model.UploadSession{}).Error
+}
+
+func (r *Repository) ListExpired(now time.Time, limit int) ([]*model.UploadSession, error) {
+	var sessions []*model.UploadSession
+
+	result := r.db.Where("expires_at < ?", now).Limit(limit).Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
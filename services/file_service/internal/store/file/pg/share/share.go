@@ -0,0 +1,99 @@
+package share
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) Create(link *model.ShareLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *Repository) GetByID(id string) (*model.ShareLink, error) {
+	link := &model.ShareLink{}
+
+	result := r.db.Where("uuid = ?", id).First(link)
+	if result.Error != nil {
+		return &model.ShareLink{}, result.Error
+	}
+	return link, nil
+}
+
+func (r *Repository) GetByTokenHash(hash string) (*model.ShareLink, error) {
+	link := &model.ShareLink{}
+
+	result := r.db.Where("token_hash = ?", hash).First(link)
+	if result.Error != nil {
+		return &model.ShareLink{}, result.Error
+	}
+	return link, nil
+}
+
+func (r *Repository) ListByFile(fileID string) ([]*model.ShareLink, error) {
+	var links []*model.ShareLink
+
+	result := r.db.Where("file_uuid = ?", fileID).Order("created_at DESC").Find(&links)
+	if result.Error != nil {
+		return []*model.ShareLink{}, result.Error
+	}
+	return links, nil
+}
+
+func (r *Repository) Delete(id string) error {
+	result := r.db.Where("uuid = ?", id).Delete(&model.ShareLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository) CountDownload(id string, served int64, size int64) error {
+	// Проверка и списание одним запросом, чтобы параллельные
+	// скачивания не превысили лимит
+	result := r.db.Model(&model.ShareLink{}).
+		Where("uuid = ? AND (max_downloads = 0 OR bytes_served + ? <= max_downloads * ?::bigint)", id, served, size).
+		Updates(map[string]any{
+			"bytes_served": gorm.Expr("bytes_served + ?", served),
+			"downloads":    gorm.Expr("(bytes_served + ?) / ?::bigint", served, size),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrLimitReached
+	}
+	return nil
+}
+
+func (r *Repository) TakePasswordAttempt(id string, limit int32, window time.Duration) error {
+	now := time.Now()
+
+	// Окно и счетчик проверяются и обновляются одним запросом, чтобы
+	// параллельные запросы не проверили больше limit паролей
+	expired := "password_window_end IS NULL OR password_window_end <= ?"
+	result := r.db.Model(&model.ShareLink{}).
+		Where("uuid = ? AND ("+expired+" OR password_attempts < ?)", id, now, limit).
+		Updates(map[string]any{
+			"password_attempts":   gorm.Expr("CASE WHEN "+expired+" THEN 1 ELSE password_attempts + 1 END", now),
+			"password_window_end": gorm.Expr("CASE WHEN "+expired+" THEN ?::timestamptz ELSE password_window_end END", now, now.Add(window)),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return store.ErrLimitReached
+	}
+	return nil
+}
+
+func (r *Repository) ReturnPasswordAttempt(id string) error {
+	return r.db.Model(&model.ShareLink{}).
+		Where("uuid = ? AND password_attempts > 0", id).
+		Update("password_attempts", gorm.Expr("password_attempts - 1")).Error
+}
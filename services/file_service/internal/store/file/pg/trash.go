@@ -0,0 +1,117 @@
+package pg
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) GetTrashed(id string) (*model.File, error) {
+	file := &model.File{}
+
+	result := r.db.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", id).First(file)
+	if result.Error != nil {
+		return &model.File{}, result.Error
+	}
+
+	if err := labelFiles(r.db, file); err != nil {
+		return &model.File{}, err
+	}
+	return file, nil
+}
+
+func (r *Repository) Restore(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&model.File{}).
+			Where("uuid = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Папка могла быть удалена, пока файл лежал в корзине
+		err := tx.Exec(`
+			UPDATE files SET folder_id = ''
+			WHERE uuid = ? AND folder_id <> ''
+			AND NOT EXISTS (SELECT 1 FROM folders WHERE folders.uuid = files.folder_id)`,
+			id,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		file := &model.File{}
+		if err := tx.Where("uuid = ?", id).First(file).Error; err != nil {
+			return err
+		}
+
+		return outbox.Emit(tx, model.EventFileRestored, file)
+	})
+}
+
+func (r *Repository) Purge(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		file := &model.File{}
+		result := tx.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", id).First(file)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		versions, err := fileVersions(tx, file)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versions {
+			if err := r.charge(tx, v.UploaderID, file.CourseID, -v.Size); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("file_uuid = ?", id).Delete(&model.FileVersion{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Delete(file).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("file_uuid = ?", id).Delete(&model.SearchDocument{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("file_uuid = ?", id).Delete(&model.FileTag{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("file_uuid = ?", id).Delete(&model.FileAttribute{}).Error; err != nil {
+			return err
+		}
+
+		if err := outbox.Emit(tx, model.EventFilePurged, file); err != nil {
+			return err
+		}
+
+		return dropOrphanTexts(tx, versions)
+	})
+}
+
+func (r *Repository) ListPurgeable(deletedBefore time.Time, afterID uint, limit int) ([]*model.File, error) {
+	var files []*model.File
+
+	result := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND id > ?", deletedBefore, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&files)
+	if result.Error != nil {
+		return []*model.File{}, result.Error
+	}
+	return files, nil
+}
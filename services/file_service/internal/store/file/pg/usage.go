@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"errors"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) Usage(scope model.UsageScope, ownerID string) (*model.Usage, error) {
+	usage := &model.Usage{}
+
+	result := r.db.Where("scope = ? AND owner_id = ?", scope, ownerID).First(usage)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return &model.Usage{}, result.Error
+	}
+
+	usage.Scope = scope
+	usage.OwnerID = ownerID
+	usage.Limit = r.limit(scope)
+
+	return usage, nil
+}
+
+// charge изменяет занятый объем загрузившего и курса на delta байт.
+// При росте объема проверяются лимиты, превышение откатывает транзакцию
+func (r *Repository) charge(tx *gorm.DB, uploaderID string, courseID string, delta int64) error {
+	if err := r.chargeOwner(tx, model.UsageScopeUser, uploaderID, delta); err != nil {
+		return err
+	}
+
+	if courseID == "" {
+		return nil
+	}
+	return r.chargeOwner(tx, model.UsageScopeCourse, courseID, delta)
+}
+
+func (r *Repository) chargeOwner(tx *gorm.DB, scope model.UsageScope, ownerID string, delta int64) error {
+	usage := &model.Usage{}
+
+	// Upsert блокирует строку до конца транзакции, поэтому параллельные
+	// загрузки одного владельца не превысят лимит вместе. Объем не уходит
+	// в минус для файлов, загруженных до появления квот
+	result := tx.Raw(`
+		INSERT INTO usages (scope, owner_id, bytes, updated_at)
+		VALUES (?, ?, GREATEST(?, 0), now())
+		ON CONFLICT (scope, owner_id) DO UPDATE
+		SET bytes = GREATEST(usages.bytes + ?, 0), updated_at = now()
+		RETURNING *`,
+		scope, ownerID, delta, delta,
+	).Scan(usage)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	limit := r.limit(scope)
+	if delta > 0 && limit > 0 && usage.Bytes > limit {
+		return store.ErrQuotaExceeded
+	}
+
+	return nil
+}
+
+func (r *Repository) limit(scope model.UsageScope) int64 {
+	switch scope {
+	case model.UsageScopeUser:
+		return r.quota.UserLimit
+	case model.UsageScopeCourse:
+		return r.quota.CourseLimit
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,227 @@
+package pg
+
+import (
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) AddVersion(
+	fileID string,
+	version *model.FileVersion,
+	keep int,
+) (*model.File, []*model.FileVersion, error) {
+
+	file := &model.File{}
+	var pruned []*model.FileVersion
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Блокировка строки файла сериализует загрузку версий
+		if err := lockFile(tx, fileID, file); err != nil {
+			return err
+		}
+
+		var last int32
+		err := tx.Model(&model.FileVersion{}).
+			Where("file_uuid = ?", fileID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		// Файлы, созданные до версионирования, получают первую версию задним числом
+		if last == 0 {
+			if err := tx.Create(versionOf(file, 1)).Error; err != nil {
+				return err
+			}
+			last = 1
+		}
+
+		version.FileUUID = fileID
+		version.Version = last + 1
+		version.ScanResult = model.ScanResult{ScanStatus: model.ScanPending}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+
+		if err := r.charge(tx, version.UploaderID, file.CourseID, version.Size); err != nil {
+			return err
+		}
+
+		applyVersion(file, version)
+		if err := tx.Save(file).Error; err != nil {
+			return err
+		}
+
+		if err := indexFiles(tx, "f.uuid = ?", fileID); err != nil {
+			return err
+		}
+
+		if err := outbox.Emit(tx, model.EventFileVersionAdded, file); err != nil {
+			return err
+		}
+
+		pruned, err = pruneVersions(tx, file, keep)
+		if err != nil {
+			return err
+		}
+
+		if err := dropOrphanTexts(tx, pruned); err != nil {
+			return err
+		}
+
+		for _, v := range pruned {
+			if err := r.charge(tx, v.UploaderID, file.CourseID, -v.Size); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, pruned, nil
+}
+
+func (r *Repository) ListVersions(fileID string) ([]*model.FileVersion, error) {
+	var versions []*model.FileVersion
+
+	result := r.db.Where("file_uuid = ?", fileID).Order("version DESC").Find(&versions)
+	if result.Error != nil {
+		return []*model.FileVersion{}, result.Error
+	}
+	return versions, nil
+}
+
+func (r *Repository) GetVersion(fileID string, version int32) (*model.FileVersion, error) {
+	v := &model.FileVersion{}
+
+	result := r.db.Where("file_uuid = ? AND version = ?", fileID, version).First(v)
+	if result.Error != nil {
+		return &model.FileVersion{}, result.Error
+	}
+	return v, nil
+}
+
+func (r *Repository) SetCurrentVersion(fileID string, version int32) (*model.File, error) {
+	file := &model.File{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockFile(tx, fileID, file); err != nil {
+			return err
+		}
+
+		v := &model.FileVersion{}
+		if err := tx.Where("file_uuid = ? AND version = ?", fileID, version).First(v).Error; err != nil {
+			return err
+		}
+
+		applyVersion(file, v)
+		if err := tx.Save(file).Error; err != nil {
+			return err
+		}
+
+		if err := indexFiles(tx, "f.uuid = ?", fileID); err != nil {
+			return err
+		}
+
+		return outbox.Emit(tx, model.EventFileVersionRestored, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := labelFiles(r.db, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// fileVersions возвращает версии файла, а для файлов, созданных
+// до версионирования, - единственную версию из самой строки файла
+func fileVersions(tx *gorm.DB, file *model.File) ([]*model.FileVersion, error) {
+	var versions []*model.FileVersion
+	if err := tx.Where("file_uuid = ?", file.UUID).Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		versions = append(versions, versionOf(file, file.CurrentVersion))
+	}
+	return versions, nil
+}
+
+func lockFile(tx *gorm.DB, fileID string, file *model.File) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", fileID).First(file).Error
+}
+
+// pruneVersions удаляет самые старые версии сверх keep, текущая версия сохраняется всегда
+func pruneVersions(tx *gorm.DB, file *model.File, keep int) ([]*model.FileVersion, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	var versions []*model.FileVersion
+	if err := tx.Where("file_uuid = ?", file.UUID).Order("version DESC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	var pruned []*model.FileVersion
+	ids := make([]uint, 0)
+
+	kept := 1
+	for _, v := range versions {
+		if v.Version == file.CurrentVersion {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+
+		pruned = append(pruned, v)
+		ids = append(ids, v.ID)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Delete(&model.FileVersion{}, ids).Error; err != nil {
+		return nil, err
+	}
+	return pruned, nil
+}
+
+func versionOf(file *model.File, version int32) *model.FileVersion {
+	return &model.FileVersion{
+		FileUUID:   file.UUID,
+		Version:    version,
+		MimeType:   file.MimeType,
+		Size:       file.Size,
+		Hash:       file.Hash,
+		CRC32C:     file.CRC32C,
+		StorageKey: file.StorageKey,
+		UploaderID: file.UploaderID,
+		CreatedAt:  file.CreatedAt,
+		ScanResult: file.ScanResult,
+
+		ContentMissing: file.ContentMissing,
+	}
+}
+
+func applyVersion(file *model.File, version *model.FileVersion) {
+	file.CurrentVersion = version.Version
+	file.MimeType = version.MimeType
+	file.Size = version.Size
+	file.Hash = version.Hash
+	file.CRC32C = version.CRC32C
+	file.StorageKey = version.StorageKey
+	file.ScanResult = version.ScanResult
+	file.ContentMissing = version.ContentMissing
+	file.PreviewState = model.PreviewPending
+	file.TextState = model.TextPending
+}
@@ -1,17 +1,21 @@
 package file
 
 import (
-	"context"
 	"fmt"
 	"time"
 
 	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
-	mn "github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/minio"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/encrypted"
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/blob"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/datakey"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/folder"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/outbox"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/session"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store/file/pg/share"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -21,9 +25,20 @@ const delay = 2 * time.Second
 
 type authStore struct {
 	metaDB *gorm.DB
-	fileDB *minio.Client
 	meta   store.MetaRepository
 	file   store.FileRepository
+
+	multipart store.MultipartRepository
+	presign   store.PresignRepository
+	sessions  store.SessionRepository
+	blobs     store.BlobRepository
+	folders   store.FolderRepository
+	links     store.ShareLinkRepository
+	outbox    store.OutboxRepository
+	keys      store.KeyRepository
+
+	// enc - nil, если шифрование не настроено
+	enc *encrypted.Repository
 }
 
 func New(logger logger.Logger, cfg Config) (store.Store, error) {
@@ -49,40 +64,76 @@ func New(logger logger.Logger, cfg Config) (store.Store, error) {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("%s:%s", cfg.MinioConfig.Host, cfg.MinioConfig.Port)
-	accessKey := cfg.MinioConfig.AccessKey
-	secretAccessKey := cfg.MinioConfig.SecretKey
-	useSSL := false
+	content, err := NewContent(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	minioDB, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretAccessKey, ""),
-		Secure: useSSL,
-	})
+	// Blob определяется ключом объекта, а не хешем: одинаковое
+	// содержимое разных курсов хранится отдельно
+	if pgDB.Migrator().HasIndex(&model.Blob{}, "idx_blobs_hash") {
+		if err := pgDB.Migrator().DropIndex(&model.Blob{}, "idx_blobs_hash"); err != nil {
+			return nil, err
+		}
+	}
+
+	err = pgDB.AutoMigrate(model.File{}, model.FileVersion{}, model.UploadSession{}, model.Blob{}, model.Usage{}, model.Folder{}, model.ShareLink{}, model.FileText{}, model.SearchDocument{}, model.FileTag{}, model.FileAttribute{}, model.OutboxEvent{}, model.DataKey{})
 	if err != nil {
 		return nil, err
 	}
 
-	exists, err := minioDB.BucketExists(context.Background(), cfg.MinioConfig.Bucket)
+	// Blob, созданные до ContentKey, хранятся под ключом содержимого
+	err = pgDB.Exec("UPDATE blobs SET content_key = storage_key WHERE content_key IS NULL").Error
 	if err != nil {
 		return nil, err
 	}
 
-	if !exists {
-		if err := minioDB.MakeBucket(context.Background(), cfg.MinioConfig.Bucket, minio.MakeBucketOptions{}); err != nil {
+	// Токены share-ссылок хранились открыто: старые ссылки продолжают
+	// работать по хешу, а столбец с токенами удаляется
+	if pgDB.Migrator().HasColumn("share_links", "token") {
+		err := pgDB.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec("UPDATE share_links SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex') WHERE token_hash IS NULL").Error
+			if err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE share_links DROP COLUMN token").Error
+		})
+		if err != nil {
 			return nil, err
 		}
 	}
 
-	err = pgDB.AutoMigrate(model.File{})
+	keys := datakey.New(pgDB, logger)
+
+	keyring, err := envelope.New(cfg.Encryption, keys)
 	if err != nil {
 		return nil, err
 	}
 
+	// Содержимое шифруется на стороне сервиса, поэтому presigned
+	// ссылки на объекты в хранилище отключаются
+	var enc *encrypted.Repository
+	if keyring != nil {
+		enc = encrypted.New(content.File, content.Multipart, keyring)
+		content.File = enc
+		content.Multipart = enc
+		content.Presign = noPresign{}
+	}
+
 	return &authStore{
 		metaDB: pgDB,
-		fileDB: minioDB,
-		meta:   pg.New(pgDB, logger),
-		file:   mn.New(minioDB, logger, cfg.MinioConfig.Bucket),
+		meta:   pg.New(pgDB, logger, cfg.Quota),
+		file:   content.File,
+
+		multipart: content.Multipart,
+		presign:   content.Presign,
+		sessions:  session.New(pgDB, logger),
+		blobs:     blob.New(pgDB, logger),
+		folders:   folder.New(pgDB, logger),
+		links:     share.New(pgDB, logger),
+		outbox:    outbox.New(pgDB, logger),
+		keys:      keys,
+		enc:       enc,
 	}, nil
 }
 
@@ -94,6 +145,52 @@ func (as *authStore) File() store.FileRepository {
 	return as.file
 }
 
+func (as *authStore) Multipart() store.MultipartRepository {
+	return as.multipart
+}
+
+func (as *authStore) CourseFile(courseID string) store.FileRepository {
+	if as.enc == nil {
+		return as.file
+	}
+	return as.enc.ForCourse(courseID)
+}
+
+func (as *authStore) CourseMultipart(courseID string) store.MultipartRepository {
+	if as.enc == nil {
+		return as.multipart
+	}
+	return as.enc.ForCourse(courseID)
+}
+
+func (as *authStore) Presign() store.PresignRepository {
+	return as.presign
+}
+
+func (as *authStore) Sessions() store.SessionRepository {
+	return as.sessions
+}
+
+func (as *authStore) Blobs() store.BlobRepository {
+	return as.blobs
+}
+
+func (as *authStore) Folders() store.FolderRepository {
+	return as.folders
+}
+
+func (as *authStore) ShareLinks() store.ShareLinkRepository {
+	return as.links
+}
+
+func (as *authStore) Outbox() store.OutboxRepository {
+	return as.outbox
+}
+
+func (as *authStore) Keys() store.KeyRepository {
+	return as.keys
+}
+
 func (as *authStore) Close() error {
 	sqlDB, _ := as.metaDB.DB()
 	err := sqlDB.Close()
@@ -2,22 +2,220 @@ package store
 
 import (
 	"io"
+	"net/http"
+	"time"
 
 	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
 )
 
+// FileRepository - хранилище содержимого. Объект становится видимым
+// только целиком после успешного Put, size -1 - размер неизвестен.
+// Get, GetRange, Stat и Copy возвращают ErrObjectNotFound, если
+// объекта нет, а Delete отсутствующего объекта не считается ошибкой
 type FileRepository interface {
 	Put(key string, reader io.Reader, size int64, contentType string) error
 	Get(key string) (io.ReadCloser, error)
+	GetRange(key string, offset, length int64) (io.ReadCloser, error)
 	Delete(key string) error
 	Stat(key string) (*model.StorageObjectInfo, error)
+	// Copy копирует объект внутри хранилища без передачи через сервис
+	Copy(srcKey string, dstKey string) error
+	// List возвращает до limit объектов с ключами после startAfter
+	// в лексикографическом порядке
+	List(prefix string, startAfter string, limit int) ([]*model.StorageObjectInfo, error)
 }
 
 type MetaRepository interface {
 	Create(file *model.File) error
 	GetByID(id string) (*model.File, error)
+	// Delete переносит файл в корзину, содержимое и версии сохраняются
 	Delete(id string) error
+	// UpdateMetadata меняет имя и тип содержимого, пустые значения не меняются
+	UpdateMetadata(id string, name string, mimeType string) error
+	// Move переносит файл, объем его версий переходит в квоту нового курса.
+	// keys заменяет ключи содержимого файла и версий, перешифрованного
+	// для нового курса
+	Move(id string, courseID string, groupID string, folderID string, keys map[string]string) error
 
-	ListByCourse(courseID string) ([]*model.File, error)
-	ListByGroup(groupID string) ([]*model.File, error)
+	// List возвращает страницу файлов и общее число подходящих под запрос
+	List(query model.FileQuery) ([]*model.File, int64, error)
+
+	// AddVersion делает version текущей версией файла и удаляет версии
+	// сверх keep (0 - без ограничения), возвращая удаленные
+	AddVersion(fileID string, version *model.FileVersion, keep int) (*model.File, []*model.FileVersion, error)
+	ListVersions(fileID string) ([]*model.FileVersion, error)
+	GetVersion(fileID string, version int32) (*model.FileVersion, error)
+	SetCurrentVersion(fileID string, version int32) (*model.File, error)
+
+	GetTrashed(id string) (*model.File, error)
+	Restore(id string) error
+	// Purge окончательно удаляет файл из корзины вместе с версиями
+	Purge(id string) error
+	// ListPurgeable возвращает до limit файлов с ID больше afterID,
+	// удаленных в корзину раньше deletedBefore, по возрастанию ID
+	ListPurgeable(deletedBefore time.Time, afterID uint, limit int) ([]*model.File, error)
+
+	// Usage возвращает занятый объем и лимит. Create и AddVersion
+	// учитывают новые версии и возвращают ErrQuotaExceeded, а место
+	// освобождается при удалении версий из хранилища (Purge и ротация)
+	Usage(scope model.UsageScope, ownerID string) (*model.Usage, error)
+
+	// ListPendingScan возвращает непроверенное содержимое файлов и версий,
+	// включая файлы в корзине. Пропавшее содержимое пропускается
+	ListPendingScan(limit int) ([]*model.ScanTarget, error)
+	// SetScanResult записывает результат всем файлам и версиям с этим
+	// содержимым, которые еще ждут проверки
+	SetScanResult(storageKey string, result model.ScanResult) error
+
+	// ListPendingPreview возвращает чистые файлы без миниатюры,
+	// по одному на содержимое. Пропавшее содержимое пропускается
+	ListPendingPreview(limit int) ([]*model.File, error)
+	// SetPreviewState записывает состояние миниатюры всем файлам с этим содержимым
+	SetPreviewState(storageKey string, state string) error
+
+	// Search ищет по имени и тексту содержимого, возвращая страницу
+	// результатов по убыванию релевантности и общее число найденных
+	Search(query model.SearchQuery) ([]*model.SearchHit, int64, error)
+	// ListPendingText возвращает чистые файлы, из содержимого которых
+	// еще не извлекался текст, по одному на содержимое. Пропавшее
+	// содержимое пропускается
+	ListPendingText(limit int) ([]*model.File, error)
+	// SetExtractedText сохраняет текст содержимого (для TextReady)
+	// и пересобирает поисковые документы всех файлов с ним
+	SetExtractedText(storageKey string, text string, state string) error
+
+	// AddTags и RemoveTags пропускают уже добавленные и отсутствующие теги
+	AddTags(fileID string, tags []string) error
+	RemoveTags(fileID string, tags []string) error
+	// SetMetadata записывает пары, пустое значение удаляет ключ,
+	// replace удаляет ключи, которых нет в metadata
+	SetMetadata(fileID string, metadata map[string]string, replace bool) error
+
+	// ReferencedKeys возвращает, на какие из keys ссылаются файлы
+	// (включая корзину), версии, blob'ы или незавершенные загрузки
+	ReferencedKeys(keys []string) (map[string]bool, error)
+	// ListStorageKeys возвращает до limit ключей содержимого файлов
+	// и версий, не отмеченного пропавшим, по возрастанию после after
+	ListStorageKeys(after string, limit int) ([]string, error)
+	// MarkContentMissing отмечает пропавшим содержимое файлов, версий
+	// и blob'а с этим ключом, возвращает число отмеченных файлов и версий
+	MarkContentMissing(storageKey string) (int64, error)
+}
+
+// PartSize - размер частей MultipartRepository, кроме последней
+// (минимально допустимый в S3). По нему шифрование вычисляет
+// смещение части из ее номера
+const PartSize = 5 << 20
+
+// MultipartRepository - загрузка объекта по частям, части нумеруются с 1
+// и все, кроме последней, размером PartSize
+type MultipartRepository interface {
+	// NewMultipart начинает загрузку объекта размером size
+	NewMultipart(key string, contentType string, size int64) (uploadID string, err error)
+	PutPart(key string, uploadID string, partNumber int, reader io.Reader, size int64) error
+	CompleteMultipart(key string, uploadID string) error
+	AbortMultipart(key string, uploadID string) error
+}
+
+// PresignRepository выпускает временные ссылки прямого доступа
+// к хранилищу, драйверы без такого доступа возвращают ErrNotSupported
+type PresignRepository interface {
+	// PresignPut возвращает ссылку и заголовки, которые клиент обязан передать
+	PresignPut(key string, contentType string, expiry time.Duration) (string, http.Header, error)
+	PresignGet(key string, contentDisposition string, expiry time.Duration) (string, error)
+}
+
+type FolderRepository interface {
+	Create(folder *model.Folder) error
+	GetByID(id string) (*model.Folder, error)
+	Rename(id string, name string) error
+	// Move меняет родителя, возвращает ErrFolderCycle, если parentID
+	// лежит внутри перемещаемой папки
+	Move(id string, parentID string) error
+	// Delete удаляет папку с подпапками, а файлы в них переносит в корзину
+	Delete(id string) (filesDeleted int64, err error)
+
+	// ListChildren возвращает подпапки parentID, а для корня - папки курса
+	// и группы; личный корень без курса и группы выбирается по creatorID
+	ListChildren(parentID string, courseID string, groupID string, creatorID string) ([]*model.Folder, error)
+
+	// Subtree возвращает папку id и всех ее потомков
+	Subtree(id string) ([]*model.Folder, error)
+}
+
+type ShareLinkRepository interface {
+	Create(link *model.ShareLink) error
+	GetByID(id string) (*model.ShareLink, error)
+	GetByTokenHash(hash string) (*model.ShareLink, error)
+	ListByFile(fileID string) ([]*model.ShareLink, error)
+	Delete(id string) error
+
+	// CountDownload списывает served байт файла размером size,
+	// возвращает ErrLimitReached, если они не укладываются в лимит
+	CountDownload(id string, served int64, size int64) error
+
+	// TakePasswordAttempt занимает попытку проверки пароля, возвращает
+	// ErrLimitReached, если limit попыток за window уже исчерпан.
+	// ReturnPasswordAttempt возвращает попытку после верного пароля
+	TakePasswordAttempt(id string, limit int32, window time.Duration) error
+	ReturnPasswordAttempt(id string) error
+}
+
+type SessionRepository interface {
+	Create(session *model.UploadSession) error
+	GetByID(id string) (*model.UploadSession, error)
+	// Advance сдвигает offset, только если текущее значение равно from
+	Advance(id string, from int64, to int64) error
+	Delete(id string) error
+
+	ListExpired(now time.Time, limit int) ([]*model.UploadSession, error)
+}
+
+// BlobRepository ведёт счётчик ссылок на общее содержимое. Blob
+// определяется ключом содержимого. put выполняется под блокировкой
+// строки, поэтому параллельные Link одного blob не гоняются за объект
+// в хранилище. remove выполняется после фиксации: blob, созданный
+// заново за это время, получит объект с другим ключом
+type BlobRepository interface {
+	// Link добавляет ссылку на blob contentKey, put записывает объект
+	// с ключом key и вызывается, только если blob новый.
+	// Пустой crc32c не меняет сумму, уже сохраненную в blob
+	Link(hash string, crc32c string, contentKey string, size int64, put func(key string) error) (*model.Blob, error)
+	// Unlink убирает ссылку на blob объекта key, remove вызывается,
+	// когда ссылок не осталось. Ошибка remove не возвращает ссылку:
+	// оставшийся объект удалит сверка хранилища
+	Unlink(key string, remove func(key string) error) error
+	// ListUnverified возвращает до limit имеющихся в хранилище blob,
+	// не сверенных с суммами после before, сначала давно не сверенные
+	ListUnverified(before time.Time, limit int) ([]*model.Blob, error)
+	// MarkVerified отмечает blob сверенным и сохраняет его CRC32C,
+	// дописывая ее файлам и версиям, у которых ее нет
+	MarkVerified(blob *model.Blob, crc32c string) error
+}
+
+// OutboxRepository - события о файлах, которые MetaRepository и
+// FolderRepository записывают в транзакциях изменений
+type OutboxRepository interface {
+	// Relay передает в publish до limit неопубликованных событий по
+	// порядку записи. publish возвращает, сколько первых событий
+	// доставлено, они помечаются опубликованными; на первом
+	// недоставленном учитывается неудачная попытка. Реплики публикуют
+	// по очереди: пока одна держит блокировку, Relay других ничего не делает
+	Relay(limit int, publish func(events []*model.OutboxEvent) (int, error)) error
+	// DeletePublished удаляет до limit событий, опубликованных раньше before
+	DeletePublished(before time.Time, limit int) (int64, error)
+}
+
+// KeyRepository хранит обернутые ключи шифрования курсов
+type KeyRepository interface {
+	// GetOrCreate возвращает ключ курса, при первом обращении
+	// сохраняя ключ из create. Из параллельных вызовов сохраняется
+	// один ключ, и все получают его
+	GetOrCreate(courseID string, create func() (*model.DataKey, error)) (*model.DataKey, error)
+	GetByID(id uint64) (*model.DataKey, error)
+	// ListNotWrappedBy возвращает до limit ключей с ID больше afterID,
+	// обернутых не мастер-ключом masterKeyID
+	ListNotWrappedBy(masterKeyID string, afterID uint64, limit int) ([]*model.DataKey, error)
+	// Rewrap заменяет обертку ключа, если он все еще обернут oldMasterKeyID
+	Rewrap(key *model.DataKey, oldMasterKeyID string) error
 }
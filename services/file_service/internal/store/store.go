@@ -3,7 +3,29 @@ package store
 type Store interface {
 	File() FileRepository
 
+	// CourseFile и CourseMultipart записывают содержимое ключом
+	// шифрования курса (пусто - вне курсов), если шифрование включено.
+	// Чтение через любой репозиторий расшифровывает содержимое сам
+	CourseFile(courseID string) FileRepository
+	CourseMultipart(courseID string) MultipartRepository
+
 	Meta() MetaRepository
 
+	Multipart() MultipartRepository
+
+	Presign() PresignRepository
+
+	Sessions() SessionRepository
+
+	Blobs() BlobRepository
+
+	Folders() FolderRepository
+
+	ShareLinks() ShareLinkRepository
+
+	Outbox() OutboxRepository
+
+	Keys() KeyRepository
+
 	Close() error
 }
@@ -0,0 +1,442 @@
+package storetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/google/uuid"
+)
+
+type check struct {
+	name string
+	run  func(s *suite) error
+}
+
+var checks = []check{
+	{"put and get", checkPutGet},
+	{"put of unknown size", checkPutUnknownSize},
+	{"overwrite", checkOverwrite},
+	{"failed put", checkFailedPut},
+	{"get range", checkGetRange},
+	{"missing object", checkMissing},
+	{"delete", checkDelete},
+	{"copy", checkCopy},
+	{"list", checkList},
+}
+
+var multipartChecks = []check{
+	{"multipart complete", checkMultipartComplete},
+	{"multipart abort", checkMultipartAbort},
+}
+
+type suite struct {
+	files     store.FileRepository
+	multipart store.MultipartRepository
+
+	prefix string
+	keys   []string
+}
+
+// Run проверяет, что драйвер хранилища ведет себя так, как ожидает
+// сервис: files и, если multipart не nil, загрузку по частям. Проверки
+// работают с ключами под случайным префиксом и удаляют их за собой,
+// поэтому Run можно запускать на рабочем хранилище. Возвращает ошибки
+// всех непрошедших проверок
+func Run(files store.FileRepository, multipart store.MultipartRepository) error {
+	s := &suite{
+		files:     files,
+		multipart: multipart,
+		prefix:    fmt.Sprintf("storetest/%s/", uuid.New()),
+	}
+	defer s.cleanup()
+
+	all := checks
+	if multipart != nil {
+		all = append(all[:len(all):len(all)], multipartChecks...)
+	}
+
+	var errs []error
+	for _, c := range all {
+		if err := c.run(s); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// key возвращает ключ проверки, он будет удален в конце Run
+func (s *suite) key(name string) string {
+	key := s.prefix + name
+	s.keys = append(s.keys, key)
+	return key
+}
+
+func (s *suite) cleanup() {
+	for _, key := range s.keys {
+		_ = s.files.Delete(key)
+	}
+}
+
+func (s *suite) put(key string, data []byte, contentType string) error {
+	if err := s.files.Put(key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	return nil
+}
+
+// expect сравнивает содержимое объекта с want
+func (s *suite) expect(key string, want []byte) error {
+	reader, err := s.files.Get(key)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", key, err)
+	}
+	defer reader.Close()
+
+	return expectReader(reader, want)
+}
+
+func expectReader(reader io.Reader, want []byte) error {
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("got %q, want %q", truncate(got), truncate(want))
+	}
+	return nil
+}
+
+func truncate(data []byte) []byte {
+	if len(data) > 32 {
+		return append(data[:32:32], "..."...)
+	}
+	return data
+}
+
+func checkPutGet(s *suite) error {
+	key := s.key("put-get")
+	data := []byte("hello, storage")
+
+	if err := s.put(key, data, "text/plain"); err != nil {
+		return err
+	}
+	if err := s.expect(key, data); err != nil {
+		return err
+	}
+
+	info, err := s.files.Stat(key)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	switch {
+	case info.Key != key:
+		return fmt.Errorf("stat key %q, want %q", info.Key, key)
+	case info.Size != int64(len(data)):
+		return fmt.Errorf("stat size %d, want %d", info.Size, len(data))
+	case info.ContentType != "text/plain":
+		return fmt.Errorf("stat content type %q, want text/plain", info.ContentType)
+	case info.ETag == "":
+		return errors.New("stat returned empty etag")
+	case info.LastModified.IsZero():
+		return errors.New("stat returned zero last modified")
+	}
+	return nil
+}
+
+func checkPutUnknownSize(s *suite) error {
+	key := s.key("unknown-size")
+	data := bytes.Repeat([]byte("0123456789"), 10_000)
+
+	// Reader без Len, чтобы драйвер не узнал размер заранее
+	reader := io.MultiReader(bytes.NewReader(data))
+	if err := s.files.Put(key, reader, -1, "application/octet-stream"); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+
+	return s.expect(key, data)
+}
+
+func checkOverwrite(s *suite) error {
+	key := s.key("overwrite")
+
+	if err := s.put(key, []byte("first"), "text/plain"); err != nil {
+		return err
+	}
+	first, err := s.files.Stat(key)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	if err := s.put(key, []byte("second version"), "text/plain"); err != nil {
+		return err
+	}
+	second, err := s.files.Stat(key)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	if first.ETag == second.ETag {
+		return errors.New("etag did not change after overwrite")
+	}
+	return s.expect(key, []byte("second version"))
+}
+
+// failingReader отдает данные и затем ошибку, как оборванный поток загрузки
+type failingReader struct {
+	data []byte
+}
+
+var errBroken = errors.New("stream broken")
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, errBroken
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func checkFailedPut(s *suite) error {
+	key := s.key("failed-put")
+	data := []byte("intact")
+
+	if err := s.put(key, data, "text/plain"); err != nil {
+		return err
+	}
+
+	err := s.files.Put(key, &failingReader{data: []byte("partial")}, -1, "text/plain")
+	if err == nil {
+		return errors.New("put from a failing reader succeeded")
+	}
+
+	// Прерванная запись не должна оставить половину объекта
+	return s.expect(key, data)
+}
+
+func checkGetRange(s *suite) error {
+	key := s.key("range")
+	data := []byte("0123456789abcdef")
+
+	if err := s.put(key, data, "text/plain"); err != nil {
+		return err
+	}
+
+	ranges := []struct {
+		offset, length int64
+		want           string
+	}{
+		{0, 4, "0123"},
+		{4, 6, "456789"},
+		{10, 0, "abcdef"},
+		{12, 100, "cdef"},
+	}
+
+	for _, rg := range ranges {
+		reader, err := s.files.GetRange(key, rg.offset, rg.length)
+		if err != nil {
+			return fmt.Errorf("range %d+%d: %w", rg.offset, rg.length, err)
+		}
+
+		err = expectReader(reader, []byte(rg.want))
+		_ = reader.Close()
+		if err != nil {
+			return fmt.Errorf("range %d+%d: %w", rg.offset, rg.length, err)
+		}
+	}
+	return nil
+}
+
+func checkMissing(s *suite) error {
+	key := s.key("missing")
+
+	if _, err := s.files.Stat(key); !errors.Is(err, store.ErrObjectNotFound) {
+		return fmt.Errorf("stat returned %v, want ErrObjectNotFound", err)
+	}
+
+	if reader, err := s.files.Get(key); !errors.Is(err, store.ErrObjectNotFound) {
+		if err == nil {
+			_ = reader.Close()
+		}
+		return fmt.Errorf("get returned %v, want ErrObjectNotFound", err)
+	}
+
+	if reader, err := s.files.GetRange(key, 1, 1); !errors.Is(err, store.ErrObjectNotFound) {
+		if err == nil {
+			_ = reader.Close()
+		}
+		return fmt.Errorf("get range returned %v, want ErrObjectNotFound", err)
+	}
+
+	if err := s.files.Copy(key, s.key("missing-copy")); !errors.Is(err, store.ErrObjectNotFound) {
+		return fmt.Errorf("copy returned %v, want ErrObjectNotFound", err)
+	}
+	return nil
+}
+
+func checkDelete(s *suite) error {
+	key := s.key("delete")
+
+	if err := s.put(key, []byte("doomed"), "text/plain"); err != nil {
+		return err
+	}
+	if err := s.files.Delete(key); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	if _, err := s.files.Stat(key); !errors.Is(err, store.ErrObjectNotFound) {
+		return fmt.Errorf("stat after delete returned %v, want ErrObjectNotFound", err)
+	}
+
+	// Повторное удаление не ошибка
+	if err := s.files.Delete(key); err != nil {
+		return fmt.Errorf("second delete: %w", err)
+	}
+	return nil
+}
+
+func checkCopy(s *suite) error {
+	src := s.key("copy-src")
+	dst := s.key("copy-dst")
+	data := []byte("copied content")
+
+	if err := s.put(src, data, "application/json"); err != nil {
+		return err
+	}
+	if err := s.files.Copy(src, dst); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+	if err := s.expect(dst, data); err != nil {
+		return err
+	}
+
+	info, err := s.files.Stat(dst)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+	if info.ContentType != "application/json" {
+		return fmt.Errorf("copy content type %q, want application/json", info.ContentType)
+	}
+
+	// Копия независима от оригинала
+	if err := s.files.Delete(src); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return s.expect(dst, data)
+}
+
+func checkList(s *suite) error {
+	prefix := s.prefix + "list/"
+
+	// Вложенные ключи и ключ-префикс другого ключа, как у миниатюр
+	names := []string{"b", "a", "c/d", "c", "c.preview.jpg", "e"}
+	for _, name := range names {
+		if err := s.put(s.key("list/"+name), []byte(name), "text/plain"); err != nil {
+			return err
+		}
+	}
+
+	// Соседний префикс не должен попасть в листинг
+	if err := s.put(s.key("listing"), []byte("x"), "text/plain"); err != nil {
+		return err
+	}
+
+	want := []string{"a", "b", "c", "c.preview.jpg", "c/d", "e"}
+
+	var got []string
+	after := ""
+	for range len(want) + 1 {
+		page, err := s.files.List(prefix, after, 2)
+		if err != nil {
+			return fmt.Errorf("list after %q: %w", after, err)
+		}
+		if len(page) > 2 {
+			return fmt.Errorf("list returned %d objects, limit is 2", len(page))
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, info := range page {
+			got = append(got, strings.TrimPrefix(info.Key, prefix))
+		}
+		after = page[len(page)-1].Key
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		return fmt.Errorf("listed %v, want %v", got, want)
+	}
+	return nil
+}
+
+func checkMultipartComplete(s *suite) error {
+	key := s.key("multipart")
+
+	first := bytes.Repeat([]byte{'a'}, store.PartSize)
+	second := []byte("tail")
+
+	uploadID, err := s.multipart.NewMultipart(key, "application/zip", int64(len(first)+len(second)))
+	if err != nil {
+		return fmt.Errorf("new multipart: %w", err)
+	}
+
+	// Части приходят в любом порядке, собираются по номерам
+	if err := s.multipart.PutPart(key, uploadID, 2, bytes.NewReader(second), int64(len(second))); err != nil {
+		return fmt.Errorf("put part 2: %w", err)
+	}
+	if err := s.multipart.PutPart(key, uploadID, 1, bytes.NewReader(first), int64(len(first))); err != nil {
+		return fmt.Errorf("put part 1: %w", err)
+	}
+
+	if _, err := s.files.Stat(key); !errors.Is(err, store.ErrObjectNotFound) {
+		return fmt.Errorf("object visible before complete: %v", err)
+	}
+
+	if err := s.multipart.CompleteMultipart(key, uploadID); err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+
+	if err := s.expect(key, append(first, second...)); err != nil {
+		return err
+	}
+
+	info, err := s.files.Stat(key)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+	if info.ContentType != "application/zip" {
+		return fmt.Errorf("content type %q, want application/zip", info.ContentType)
+	}
+	return nil
+}
+
+func checkMultipartAbort(s *suite) error {
+	key := s.key("multipart-abort")
+
+	uploadID, err := s.multipart.NewMultipart(key, "text/plain", 4)
+	if err != nil {
+		return fmt.Errorf("new multipart: %w", err)
+	}
+
+	if err := s.multipart.PutPart(key, uploadID, 1, strings.NewReader("part"), 4); err != nil {
+		return fmt.Errorf("put part: %w", err)
+	}
+
+	if err := s.multipart.AbortMultipart(key, uploadID); err != nil {
+		return fmt.Errorf("abort: %w", err)
+	}
+
+	if err := s.multipart.CompleteMultipart(key, uploadID); err == nil {
+		return errors.New("complete after abort succeeded")
+	}
+
+	if _, err := s.files.Stat(key); !errors.Is(err, store.ErrObjectNotFound) {
+		return fmt.Errorf("stat after abort returned %v, want ErrObjectNotFound", err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package indexer
+
+import "time"
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"30s"`
+	BatchSize int           `yaml:"batch_size" env-default:"20"`
+
+	// Оригиналы больше MaxSourceSize байт не разбираются,
+	// извлеченный текст обрезается до MaxTextSize байт
+	MaxSourceSize int64 `yaml:"max_source_size" env-default:"20971520"`
+	MaxTextSize   int   `yaml:"max_text_size" env-default:"1048576"`
+}
@@ -0,0 +1,109 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/textract"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Indexer извлекает текст из проверенных документов для полнотекстового поиска
+type Indexer struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Indexer {
+	return &Indexer{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "search indexer"),
+	}
+}
+
+func (i *Indexer) Run(ctx context.Context) {
+	worker.Every(ctx, i.cfg.Interval, i.index)
+}
+
+func (i *Indexer) index(ctx context.Context) {
+	for ctx.Err() == nil {
+		files, err := i.store.Meta().ListPendingText(i.cfg.BatchSize)
+		if err != nil {
+			i.logger.Errorf("failed to list files pending indexing: %s", err)
+			return
+		}
+
+		if len(files) == 0 {
+			return
+		}
+
+		var failed int
+		for _, file := range files {
+			text, state, err := i.extract(file)
+			if err != nil {
+				// Хранилище недоступно: остальной пакет обрабатываем,
+				// этот файл повторим на следующем тике
+				i.logger.Errorf("failed to extract text of %s: %s", file.StorageKey, err)
+				failed++
+				continue
+			}
+
+			if err := i.store.Meta().SetExtractedText(file.StorageKey, text, state); err != nil {
+				i.logger.Errorf("failed to save text of %s: %s", file.StorageKey, err)
+				return
+			}
+		}
+
+		// Неудавшиеся файлы вернутся в следующем пакете, ждем тика
+		if failed > 0 {
+			return
+		}
+	}
+}
+
+// extract возвращает текст содержимого file и новое состояние
+func (i *Indexer) extract(file *model.File) (string, string, error) {
+	if !textract.Supported(file.MimeType) || file.Size > i.cfg.MaxSourceSize {
+		return "", model.TextNone, nil
+	}
+
+	reader, err := i.store.File().Get(file.StorageKey)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		i.logger.Warnf("no text in %s: content is missing", file.StorageKey)
+		return "", model.TextFailed, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	defer reader.Close()
+
+	src, err := io.ReadAll(io.LimitReader(reader, i.cfg.MaxSourceSize))
+	if errors.Is(err, envelope.ErrCorrupted) {
+		i.logger.Warnf("no text in %s: %s", file.StorageKey, err)
+		return "", model.TextFailed, nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("read failed: %w", err)
+	}
+
+	text, err := textract.Extract(src, file.MimeType, i.cfg.MaxTextSize)
+	if errors.Is(err, textract.ErrUnsupported) {
+		i.logger.Warnf("no text in %s: %s", file.StorageKey, err)
+		return "", model.TextNone, nil
+	}
+	if err != nil {
+		// Содержимое не разбирается, повтор не поможет
+		i.logger.Warnf("failed to extract text of %s: %s", file.StorageKey, err)
+		return "", model.TextFailed, nil
+	}
+
+	return text, model.TextReady, nil
+}
@@ -0,0 +1,16 @@
+package previewer
+
+import "time"
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"30s"`
+	BatchSize int           `yaml:"batch_size" env-default:"20"`
+
+	// Size - максимальная сторона миниатюры в пикселях
+	Size    int `yaml:"size" env-default:"256"`
+	Quality int `yaml:"quality" env-default:"80"`
+
+	// Оригиналы больше MaxSourceSize байт или MaxPixels пикселей пропускаются
+	MaxSourceSize int64 `yaml:"max_source_size" env-default:"52428800"`
+	MaxPixels     int   `yaml:"max_pixels" env-default:"40000000"`
+}
@@ -0,0 +1,127 @@
+package previewer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/preview"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Previewer строит миниатюры проверенных файлов и кладет их в MinIO
+// рядом с оригиналом
+type Previewer struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Previewer {
+	return &Previewer{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "previewer"),
+	}
+}
+
+func (p *Previewer) Run(ctx context.Context) {
+	worker.Every(ctx, p.cfg.Interval, p.generate)
+}
+
+func (p *Previewer) generate(ctx context.Context) {
+	for ctx.Err() == nil {
+		files, err := p.store.Meta().ListPendingPreview(p.cfg.BatchSize)
+		if err != nil {
+			p.logger.Errorf("failed to list files pending preview: %s", err)
+			return
+		}
+
+		if len(files) == 0 {
+			return
+		}
+
+		var failed int
+		for _, file := range files {
+			state, err := p.render(file)
+			if err != nil {
+				// Хранилище недоступно: остальной пакет обрабатываем,
+				// этот файл повторим на следующем тике
+				p.logger.Errorf("failed to build preview of %s: %s", file.StorageKey, err)
+				failed++
+				continue
+			}
+
+			if err := p.store.Meta().SetPreviewState(file.StorageKey, state); err != nil {
+				p.logger.Errorf("failed to save preview state of %s: %s", file.StorageKey, err)
+				return
+			}
+		}
+
+		// Неудавшиеся файлы вернутся в следующем пакете, ждем тика
+		if failed > 0 {
+			return
+		}
+	}
+}
+
+// render строит миниатюру содержимого file и возвращает новое состояние
+func (p *Previewer) render(file *model.File) (string, error) {
+	if !preview.Supported(file.MimeType) || file.Size > p.cfg.MaxSourceSize {
+		return model.PreviewNone, nil
+	}
+
+	key := preview.Key(file.StorageKey)
+
+	// Миниатюра уже есть, например после восстановления старой версии
+	if _, err := p.store.File().Stat(key); err == nil {
+		return model.PreviewReady, nil
+	}
+
+	reader, err := p.store.File().Get(file.StorageKey)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		p.logger.Warnf("no preview for %s: content is missing", file.StorageKey)
+		return model.PreviewFailed, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	src, err := preview.ReadSource(reader, p.cfg.MaxSourceSize)
+	if errors.Is(err, envelope.ErrCorrupted) {
+		p.logger.Warnf("no preview for %s: %s", file.StorageKey, err)
+		return model.PreviewFailed, nil
+	}
+	if err != nil && !errors.Is(err, preview.ErrUnsupported) {
+		return "", err
+	}
+
+	var thumb []byte
+	if err == nil {
+		thumb, err = preview.Thumbnail(src, preview.Options{
+			Size:      p.cfg.Size,
+			MaxPixels: p.cfg.MaxPixels,
+			Quality:   p.cfg.Quality,
+		})
+	}
+	if errors.Is(err, preview.ErrUnsupported) {
+		p.logger.Warnf("no preview for %s: %s", file.StorageKey, err)
+		return model.PreviewNone, nil
+	}
+	if err != nil {
+		// Содержимое не разбирается, повтор не поможет
+		p.logger.Warnf("failed to render preview of %s: %s", file.StorageKey, err)
+		return model.PreviewFailed, nil
+	}
+
+	if err := p.store.CourseFile(file.CourseID).Put(key, bytes.NewReader(thumb), int64(len(thumb)), preview.MimeType); err != nil {
+		return "", err
+	}
+
+	return model.PreviewReady, nil
+}
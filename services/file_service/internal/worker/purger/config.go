@@ -0,0 +1,9 @@
+package purger
+
+import "time"
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"1h"`
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+}
@@ -0,0 +1,62 @@
+package purger
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/content"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Purger окончательно удаляет файлы, пролежавшие в корзине дольше Retention
+type Purger struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Purger {
+	return &Purger{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "trash purger"),
+	}
+}
+
+func (p *Purger) Run(ctx context.Context) {
+	worker.Every(ctx, p.cfg.Interval, p.purge)
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	deletedBefore := time.Now().Add(-p.cfg.Retention)
+
+	// Курсор по ID пропускает файлы, которые не удалось удалить: иначе
+	// они возвращались бы в каждом пакете и цикл не завершился бы
+	var afterID uint
+	for ctx.Err() == nil {
+		files, err := p.store.Meta().ListPurgeable(deletedBefore, afterID, p.cfg.BatchSize)
+		if err != nil {
+			p.logger.Errorf("failed to list purgeable files: %s", err)
+			return
+		}
+
+		if len(files) == 0 {
+			return
+		}
+		afterID = files[len(files)-1].ID
+
+		var failed int
+		for _, file := range files {
+			// Неудавшийся файл не мешает остальным, его повторим на следующем тике
+			if err := content.Purge(p.store, p.logger, file); err != nil {
+				p.logger.Errorf("failed to purge file %s: %s", file.UUID, err)
+				failed++
+				continue
+			}
+		}
+
+		p.logger.Infof("purged %d files from trash", len(files)-failed)
+	}
+}
@@ -0,0 +1,16 @@
+package reconciler
+
+import "time"
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"24h"`
+	BatchSize int           `yaml:"batch_size" env-default:"1000"`
+
+	// Объекты моложе GracePeriod не считаются осиротевшими: это могут
+	// быть загрузки, строки которых еще не записаны
+	GracePeriod time.Duration `yaml:"grace_period" env-default:"24h"`
+
+	// Repair удаляет осиротевшие объекты и отмечает файлы с пропавшим
+	// содержимым, иначе расхождения только попадают в отчет
+	Repair bool `yaml:"repair" env-default:"false"`
+}
@@ -0,0 +1,209 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/preview"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// В отчет попадает не больше maxSample ключей каждого вида
+const maxSample = 1000
+
+// Reconciler сверяет хранилище с базой: находит объекты, на которые
+// не ссылается ни одна строка, и строки, объектов которых нет
+type Reconciler struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+// Report - результат сверки
+type Report struct {
+	Objects int64 // просмотрено объектов хранилища
+	Keys    int64 // просмотрено ключей содержимого в базе
+
+	// Orphans - объекты без строк, OrphanSize - их объем в байтах
+	Orphans    int64
+	OrphanSize int64
+	// Missing - ключи содержимого, объектов которых нет в хранилище
+	Missing int64
+
+	// Результат исправления
+	Deleted int64 // удалено объектов
+	Marked  int64 // отмечено файлов и версий
+
+	OrphanKeys  []string
+	MissingKeys []string
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Reconciler {
+	return &Reconciler{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "reconciler"),
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context) {
+	worker.Every(ctx, r.cfg.Interval, r.reconcile)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) {
+	report, err := r.Reconcile(ctx, r.cfg.Repair)
+	if err != nil {
+		r.logger.Errorf("reconciliation failed: %s", err)
+		return
+	}
+
+	if report.Orphans > 0 || report.Missing > 0 {
+		r.logger.Warnf(
+			"reconciled %d objects and %d keys: %d orphans (%d bytes, %d deleted), %d missing (%d rows marked)",
+			report.Objects, report.Keys, report.Orphans, report.OrphanSize, report.Deleted, report.Missing, report.Marked,
+		)
+		return
+	}
+
+	r.logger.Infof("reconciled %d objects and %d keys, no discrepancies", report.Objects, report.Keys)
+}
+
+// Reconcile проходит хранилище и таблицы файлов и версий страницами
+// по BatchSize. При repair осиротевшие объекты удаляются, а файлы
+// и версии с пропавшим содержимым отмечаются, скачать их нельзя
+func (r *Reconciler) Reconcile(ctx context.Context, repair bool) (*Report, error) {
+	report := &Report{}
+
+	if err := r.reconcileObjects(ctx, report, repair); err != nil {
+		return report, err
+	}
+
+	if err := r.reconcileKeys(ctx, report, repair); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// reconcileObjects ищет объекты, на которые не ссылается ни одна строка
+func (r *Reconciler) reconcileObjects(ctx context.Context, report *Report, repair bool) error {
+	cutoff := time.Now().Add(-r.cfg.GracePeriod)
+
+	var after string
+	for ctx.Err() == nil {
+		objects, err := r.store.File().List("", after, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(objects) == 0 {
+			return nil
+		}
+		after = objects[len(objects)-1].Key
+		report.Objects += int64(len(objects))
+
+		// Миниатюра нужна, пока есть ее оригинал
+		keys := make([]string, 0, len(objects))
+		for _, object := range objects {
+			keys = append(keys, sourceKey(object.Key))
+		}
+
+		referenced, err := r.store.Meta().ReferencedKeys(keys)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if referenced[sourceKey(object.Key)] || object.LastModified.After(cutoff) {
+				continue
+			}
+
+			r.orphan(report, object, repair)
+		}
+
+		if len(objects) < r.cfg.BatchSize {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (r *Reconciler) orphan(report *Report, object *model.StorageObjectInfo, repair bool) {
+	report.Orphans++
+	report.OrphanSize += object.Size
+	if len(report.OrphanKeys) < maxSample {
+		report.OrphanKeys = append(report.OrphanKeys, object.Key)
+	}
+
+	if !repair {
+		return
+	}
+
+	if err := r.store.File().Delete(object.Key); err != nil {
+		r.logger.Warnf("failed to delete orphaned object %s: %s", object.Key, err)
+		return
+	}
+	report.Deleted++
+}
+
+// reconcileKeys ищет файлы и версии, объектов которых нет в хранилище
+func (r *Reconciler) reconcileKeys(ctx context.Context, report *Report, repair bool) error {
+	var after string
+	for ctx.Err() == nil {
+		keys, err := r.store.Meta().ListStorageKeys(after, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		after = keys[len(keys)-1]
+		report.Keys += int64(len(keys))
+
+		for _, key := range keys {
+			_, err := r.store.File().Stat(key)
+			if errors.Is(err, store.ErrObjectNotFound) {
+				r.missing(report, key, repair)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(keys) < r.cfg.BatchSize {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (r *Reconciler) missing(report *Report, key string, repair bool) {
+	report.Missing++
+	if len(report.MissingKeys) < maxSample {
+		report.MissingKeys = append(report.MissingKeys, key)
+	}
+
+	if !repair {
+		return
+	}
+
+	marked, err := r.store.Meta().MarkContentMissing(key)
+	if err != nil {
+		r.logger.Warnf("failed to mark content %s missing: %s", key, err)
+		return
+	}
+	report.Marked += marked
+}
+
+func sourceKey(key string) string {
+	if source, ok := preview.SourceKey(key); ok {
+		return source
+	}
+	return key
+}
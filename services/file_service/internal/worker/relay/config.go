@@ -0,0 +1,19 @@
+package relay
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/events/nats"
+)
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"2s"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+
+	// Сколько хранить опубликованные события
+	Retention time.Duration `yaml:"retention" env-default:"168h"`
+
+	// Publisher - log (события пишутся в лог сервиса) или nats
+	Publisher string      `yaml:"publisher" env-default:"log" validate:"oneof=log nats"`
+	NATS      nats.Config `yaml:"nats"`
+}
@@ -0,0 +1,89 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/events"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/events/nats"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Relay публикует события из outbox и удаляет опубликованные
+// старше Retention. Недоставленное событие останавливает публикацию
+// до следующего тика, чтобы получатели видели события по порядку
+type Relay struct {
+	store     store.Store
+	publisher events.EventPublisher
+	cfg       Config
+	logger    logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Relay {
+	r := &Relay{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "outbox relay"),
+	}
+
+	switch cfg.Publisher {
+	case "nats":
+		r.publisher = nats.New(cfg.NATS)
+	default:
+		r.publisher = events.NewLocal(logger)
+	}
+
+	return r
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	defer func() {
+		if err := r.publisher.Close(); err != nil {
+			r.logger.Warnf("failed to close event publisher: %s", err)
+		}
+	}()
+
+	worker.Every(ctx, r.cfg.Interval, r.relay)
+}
+
+func (r *Relay) relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		var published int
+
+		err := r.store.Outbox().Relay(r.cfg.BatchSize, func(batch []*model.OutboxEvent) (int, error) {
+			for i, event := range batch {
+				if err := r.publisher.Publish(ctx, event); err != nil {
+					return i, err
+				}
+				published++
+			}
+			return len(batch), nil
+		})
+		if err != nil {
+			// брокер недоступен, повторим на следующем тике
+			r.logger.Errorf("failed to relay events: %s", err)
+			return
+		}
+
+		if published < r.cfg.BatchSize {
+			break
+		}
+	}
+
+	r.cleanup()
+}
+
+func (r *Relay) cleanup() {
+	deleted, err := r.store.Outbox().DeletePublished(time.Now().Add(-r.cfg.Retention), r.cfg.BatchSize*10)
+	if err != nil {
+		r.logger.Errorf("failed to delete published events: %s", err)
+		return
+	}
+
+	if deleted > 0 {
+		r.logger.Infof("deleted %d published events", deleted)
+	}
+}
@@ -0,0 +1,18 @@
+package scanner
+
+import (
+	"time"
+
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan/clamd"
+)
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"10s"`
+	BatchSize int           `yaml:"batch_size" env-default:"20"`
+
+	// Driver - clamd, eicar (тестовый, ищет только строку EICAR)
+	// или none (проверка отключена, любое содержимое считается чистым).
+	// По умолчанию none, чтобы конфигурации без сканера продолжали работать
+	Driver string       `yaml:"driver" env-default:"none" validate:"oneof=clamd eicar none"`
+	Clamd  clamd.Config `yaml:"clamd"`
+}
@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan/clamd"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/scan/eicar"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Scanner проверяет новое содержимое и записывает результат в метаданные.
+// До проверки файлы остаются в статусе pending_scan и не скачиваются
+type Scanner struct {
+	store  store.Store
+	engine scan.Scanner
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Scanner {
+	s := &Scanner{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "malware scanner"),
+	}
+
+	switch cfg.Driver {
+	case "clamd":
+		s.engine = clamd.New(cfg.Clamd)
+	case "eicar":
+		s.engine = eicar.New()
+	case "none":
+		s.logger.Warn("malware scanning is disabled, all content is marked clean")
+		s.engine = scan.Noop{}
+	}
+
+	return s
+}
+
+func (s *Scanner) Run(ctx context.Context) {
+	worker.Every(ctx, s.cfg.Interval, s.scan)
+}
+
+func (s *Scanner) scan(ctx context.Context) {
+	for ctx.Err() == nil {
+		targets, err := s.store.Meta().ListPendingScan(s.cfg.BatchSize)
+		if err != nil {
+			s.logger.Errorf("failed to list content pending scan: %s", err)
+			return
+		}
+
+		if len(targets) == 0 {
+			return
+		}
+
+		var failed int
+		for _, target := range targets {
+			result, err := s.check(ctx, target)
+			if err != nil {
+				// Сканер или хранилище недоступны: остальной пакет
+				// проверяем, эту цель повторим на следующем тике
+				s.logger.Errorf("failed to scan %s: %s", target.StorageKey, err)
+				failed++
+				continue
+			}
+
+			if err := s.store.Meta().SetScanResult(target.StorageKey, result); err != nil {
+				s.logger.Errorf("failed to save scan result of %s: %s", target.StorageKey, err)
+				return
+			}
+
+			if result.ScanStatus == model.ScanInfected {
+				s.logger.Warnf("content %s is infected: %s", target.StorageKey, result.ScanSignature)
+			}
+		}
+
+		// Неудавшиеся цели вернутся в следующем пакете, ждем тика
+		if failed > 0 {
+			return
+		}
+	}
+}
+
+func (s *Scanner) check(ctx context.Context, target *model.ScanTarget) (model.ScanResult, error) {
+	now := time.Now()
+	result := model.ScanResult{ScannedAt: &now}
+
+	// Пропавшее или поврежденное содержимое проверить уже нельзя
+	reader, err := s.store.File().Get(target.StorageKey)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		s.logger.Warnf("content %s is missing from storage", target.StorageKey)
+		result.ScanStatus = model.ScanFailed
+		return result, nil
+	}
+	if err != nil {
+		return model.ScanResult{}, err
+	}
+	defer reader.Close()
+
+	verdict, err := s.engine.Scan(ctx, reader)
+
+	switch {
+	case errors.Is(err, scan.ErrUnscannable), errors.Is(err, envelope.ErrCorrupted):
+		s.logger.Warnf("content %s cannot be scanned: %s", target.StorageKey, err)
+		result.ScanStatus = model.ScanFailed
+	case err != nil:
+		return model.ScanResult{}, err
+	case verdict.Infected:
+		result.ScanStatus = model.ScanInfected
+		result.ScanSignature = verdict.Signature
+	default:
+		result.ScanStatus = model.ScanClean
+	}
+
+	return result, nil
+}
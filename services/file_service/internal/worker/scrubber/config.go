@@ -0,0 +1,17 @@
+package scrubber
+
+import "time"
+
+type Config struct {
+	// За запуск перепроверяется до BatchSize blob, давно не сверенные первыми
+	Interval  time.Duration `yaml:"interval" env-default:"1h"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+
+	// MaxAge - как часто перепроверять один и тот же blob
+	MaxAge time.Duration `yaml:"max_age" env-default:"720h"`
+
+	// Repair отмечает файлы с поврежденным содержимым пропавшими:
+	// скачать их нельзя, следующая загрузка того же содержимого
+	// запишет объект заново. Иначе повреждения только логируются
+	Repair bool `yaml:"repair" env-default:"false"`
+}
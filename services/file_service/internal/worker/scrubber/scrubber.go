@@ -0,0 +1,117 @@
+package scrubber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/checksum"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/envelope"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/domain/model"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// errDamaged - содержимое объекта не сходится с суммами blob
+var errDamaged = errors.New("content is damaged")
+
+// Scrubber перечитывает содержимое blob из хранилища и сверяет его
+// с SHA-256 и CRC32C, сохраненными при загрузке. CRC32C содержимого,
+// загруженного до ее появления, считается при первой проверке
+type Scrubber struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Scrubber {
+	return &Scrubber{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "scrubber"),
+	}
+}
+
+func (s *Scrubber) Run(ctx context.Context) {
+	worker.Every(ctx, s.cfg.Interval, s.scrub)
+}
+
+func (s *Scrubber) scrub(ctx context.Context) {
+	blobs, err := s.store.Blobs().ListUnverified(time.Now().Add(-s.cfg.MaxAge), s.cfg.BatchSize)
+	if err != nil {
+		s.logger.Errorf("failed to list blobs: %s", err)
+		return
+	}
+
+	var damaged int
+	for _, blob := range blobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sum, err := s.verify(blob)
+		if errors.Is(err, errDamaged) {
+			damaged++
+			s.damaged(blob, err)
+			// Blob уходит в конец очереди, чтобы не занимать каждый запуск
+			sum.CRC32C = blob.CRC32C
+		} else if err != nil {
+			// Хранилище недоступно, blob проверится в следующий раз
+			s.logger.Warnf("failed to verify blob %s: %s", blob.Hash, err)
+			continue
+		}
+
+		if err := s.store.Blobs().MarkVerified(blob, sum.CRC32C); err != nil {
+			s.logger.Errorf("failed to mark blob %s verified: %s", blob.Hash, err)
+			return
+		}
+	}
+
+	if len(blobs) > 0 {
+		s.logger.Infof("verified %d blobs, %d damaged", len(blobs), damaged)
+	}
+}
+
+// verify считает суммы объекта blob и сверяет их с сохраненными
+func (s *Scrubber) verify(blob *model.Blob) (checksum.Sum, error) {
+	reader, err := s.store.File().Get(blob.StorageKey)
+	if errors.Is(err, store.ErrObjectNotFound) {
+		return checksum.Sum{}, fmt.Errorf("%w: object is missing", errDamaged)
+	}
+	if err != nil {
+		return checksum.Sum{}, err
+	}
+	defer reader.Close()
+
+	sum, err := checksum.Read(reader)
+	if errors.Is(err, envelope.ErrCorrupted) {
+		return checksum.Sum{}, fmt.Errorf("%w: %s", errDamaged, err)
+	}
+	if err != nil {
+		return checksum.Sum{}, err
+	}
+
+	expected := checksum.Sum{SHA256: blob.Hash, CRC32C: blob.CRC32C}
+	if mismatch := expected.Mismatch(sum); mismatch != "" {
+		return checksum.Sum{}, fmt.Errorf("%w: %s mismatch", errDamaged, mismatch)
+	}
+
+	return sum, nil
+}
+
+func (s *Scrubber) damaged(blob *model.Blob, err error) {
+	s.logger.Errorf("blob %s at %s: %s", blob.Hash, blob.StorageKey, err)
+
+	if !s.cfg.Repair {
+		return
+	}
+
+	marked, err := s.store.Meta().MarkContentMissing(blob.StorageKey)
+	if err != nil {
+		s.logger.Errorf("failed to mark content of blob %s missing: %s", blob.Hash, err)
+		return
+	}
+	s.logger.Warnf("marked %d files and versions of blob %s as missing", marked, blob.Hash)
+}
@@ -0,0 +1,8 @@
+package sessiongc
+
+import "time"
+
+type Config struct {
+	Interval  time.Duration `yaml:"interval" env-default:"10m"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+}
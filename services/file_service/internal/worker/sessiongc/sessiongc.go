@@ -0,0 +1,64 @@
+package sessiongc
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexey-dobry/fileshare/pkg/logger"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/store"
+	"github.com/alexey-dobry/fileshare/services/file_service/internal/worker"
+)
+
+// Collector удаляет просроченные сессии загрузки вместе с их
+// незавершенными multipart upload или неподтвержденными объектами в MinIO
+type Collector struct {
+	store  store.Store
+	cfg    Config
+	logger logger.Logger
+}
+
+func New(logger logger.Logger, store store.Store, cfg Config) *Collector {
+	return &Collector{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.WithFields("layer", "session gc"),
+	}
+}
+
+func (c *Collector) Run(ctx context.Context) {
+	worker.Every(ctx, c.cfg.Interval, c.collect)
+}
+
+func (c *Collector) collect(ctx context.Context) {
+	for ctx.Err() == nil {
+		sessions, err := c.store.Sessions().ListExpired(time.Now(), c.cfg.BatchSize)
+		if err != nil {
+			c.logger.Errorf("failed to list expired sessions: %s", err)
+			return
+		}
+
+		if len(sessions) == 0 {
+			return
+		}
+
+		for _, session := range sessions {
+			// Upload мог быть уже удален в MinIO, строку все равно убираем
+			var err error
+			if session.Presigned {
+				err = c.store.File().Delete(session.StorageKey)
+			} else {
+				err = c.store.Multipart().AbortMultipart(session.StorageKey, session.UploadID)
+			}
+			if err != nil {
+				c.logger.Warnf("failed to abort upload of session %s: %s", session.UUID, err)
+			}
+
+			if err := c.store.Sessions().Delete(session.UUID); err != nil {
+				c.logger.Errorf("failed to delete session %s: %s", session.UUID, err)
+				return
+			}
+		}
+
+		c.logger.Infof("collected %d expired upload sessions", len(sessions))
+	}
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Worker - фоновая задача, работающая до отмены ctx
+type Worker interface {
+	Run(ctx context.Context)
+}
+
+// Every вызывает fn сразу и затем каждые interval, пока ctx не отменен
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -192,3 +192,46 @@ func (s *InternalServer) DetachStudentToCourse(ctx context.Context, req *pb.Deta
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *InternalServer) GetUserAccess(ctx context.Context, req *pb.GetUserAccessRequest) (*pb.GetUserAccessResponse, error) {
+	user, err := s.store.User().GetUserByID(req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	teacherCourses, err := s.store.Course().GetTeacherCoursesByUserID(req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	courses, err := s.store.Course().GetCoursesByUserID(req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	groups, err := s.store.Group().GetGroupsByUserID(req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	resp := &pb.GetUserAccessResponse{
+		Role:             user.Role,
+		TeacherCourseIDs: make([]string, 0, len(teacherCourses)),
+		CourseIDs:        make([]string, 0, len(courses)),
+		GroupIDs:         make([]string, 0, len(groups)),
+	}
+
+	for _, course := range teacherCourses {
+		resp.TeacherCourseIDs = append(resp.TeacherCourseIDs, course.ID)
+	}
+
+	for _, course := range courses {
+		resp.CourseIDs = append(resp.CourseIDs, course.ID)
+	}
+
+	for _, group := range groups {
+		resp.GroupIDs = append(resp.GroupIDs, group.ID)
+	}
+
+	return resp, nil
+}
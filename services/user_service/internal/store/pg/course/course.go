@@ -63,6 +63,41 @@ func (r *Repository) GetCoursesByUserID(userID string) ([]models.Course, error)
 	return result, nil
 }
 
+func (r *Repository) GetTeacherCoursesByUserID(userID string) ([]models.Course, error) {
+	result := make([]models.Course, 0)
+
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	// build query
+	query, args, err := psql.Select("c.id", "c.name", "c.created_at").
+		From("teacher_course tc").
+		Join("course c ON c.id = tc.course_id").
+		Where(squirrel.Eq{"tc.user_id": userID}).
+		ToSql()
+	if err != nil {
+		return []models.Course{}, fmt.Errorf("failed to create sql query: %s", err)
+	}
+
+	// executing query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return []models.Course{}, fmt.Errorf("failed to query data: %s", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.Course
+		err = rows.Scan(&c.ID, &c.Name, &c.CreatedAt)
+		if err != nil {
+			return []models.Course{}, fmt.Errorf("failed to marshall data: %s", err)
+		}
+
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
 func (r *Repository) AssignTeacherToCourse(teacherID, courseID string) error {
 	// making query builder
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
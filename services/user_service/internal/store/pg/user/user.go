@@ -33,7 +33,7 @@ func (r *Repository) GetUserByID(ID string) (models.User, error) {
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	// build query
-	query, args, err := psql.Select("name", "surname", "email").
+	query, args, err := psql.Select("uuid", "name", "surname", "email", "role").
 		From("users").
 		Where(squirrel.Eq{"uuid": ID}).
 		ToSql()
@@ -44,7 +44,7 @@ func (r *Repository) GetUserByID(ID string) (models.User, error) {
 	// executing query
 	row := r.db.QueryRow(query, args...)
 
-	err = row.Scan(&u.Name, &u.Surname, &u.Email)
+	err = row.Scan(&u.ID, &u.Name, &u.Surname, &u.Email, &u.Role)
 	if err != nil {
 		return u, err
 	}
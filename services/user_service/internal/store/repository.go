@@ -32,6 +32,7 @@ type GroupRepository interface {
 type CourseRepository interface {
 	CreateCourse(courseData models.Course) error
 	GetCoursesByUserID(userID string) ([]models.Course, error)
+	GetTeacherCoursesByUserID(userID string) ([]models.Course, error)
 	GetCourses() ([]models.Course, error)
 
 	AssignTeacherToCourse(teacherID, courseID string) error